@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/agatticelli/trading-cli/internal/executor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditFix         bool
+	auditStopPercent float64
+	auditStopATR     float64
+	auditATRPeriod   int
+	auditATRInterval string
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Find unprotected positions and orphaned orders",
+	Long: `Scans every account for:
+  - positions without a stop loss or take profit
+  - stops whose size doesn't match the position
+  - reduce-only orders left behind with no position
+
+With --fix, attaches a default stop (percent or ATR based) to unprotected
+positions and cancels orphaned orders.
+
+Examples:
+  # Report only
+  trading-cli audit
+
+  # Attach 2% stops and cancel orphans
+  trading-cli audit --fix --stop-pct 2
+
+  # Attach stops 1.5 ATR away from entry, on the configured ATR settings
+  trading-cli audit --fix --stop-atr 1.5
+
+  # Same, on ATR(21) of the 4h chart
+  trading-cli audit --fix --stop-atr 1.5 --atr-period 21 --atr-interval 4h`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exec := getExecutor()
+
+		if auditStopPercent < 0 || auditStopPercent >= 100 {
+			return fmt.Errorf("stop-pct must be between 0 and 100")
+		}
+		if auditStopATR < 0 {
+			return fmt.Errorf("stop-atr must be positive")
+		}
+		if auditATRPeriod < 0 {
+			return fmt.Errorf("atr-period must be positive")
+		}
+
+		return exec.ExecuteAudit(cmd.Context(), executor.AuditOptions{
+			Fix:         auditFix,
+			StopPercent: auditStopPercent,
+			StopATR:     auditStopATR,
+			ATRPeriod:   auditATRPeriod,
+			ATRInterval: auditATRInterval,
+		})
+	},
+}
+
+func init() {
+	auditCmd.Flags().BoolVar(&auditFix, "fix", false, "Attach default stops and cancel orphaned orders")
+	auditCmd.Flags().Float64Var(&auditStopPercent, "stop-pct", 0, "Default stop distance from entry in percent")
+	auditCmd.Flags().Float64Var(&auditStopATR, "stop-atr", 0, "Default stop distance in ATR multiples (overrides --stop-pct)")
+	auditCmd.Flags().IntVar(&auditATRPeriod, "atr-period", 0, "ATR period for --stop-atr (default: config, else 14)")
+	auditCmd.Flags().StringVar(&auditATRInterval, "atr-interval", "", "Candle interval for --stop-atr (default: config, else 1h)")
+}
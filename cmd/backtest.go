@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/agatticelli/trading-cli/internal/backtest"
+	"github.com/agatticelli/trading-cli/internal/executor"
+	"github.com/agatticelli/trading-cli/internal/levels"
+	"github.com/agatticelli/trading-cli/internal/sizing"
+	"github.com/agatticelli/trading-cli/internal/strategies"
+	"github.com/agatticelli/trading-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backtestCandles   string
+	backtestSignals   string
+	backtestRule      string
+	backtestSymbol    string
+	backtestBalance   float64
+	backtestRisk      float64
+	backtestRR        float64
+	backtestSL        string
+	backtestTP        string
+	backtestATRPeriod int
+	backtestFee       float64
+	backtestSlippage  float64
+	backtestCompound  bool
+)
+
+var backtestCmd = &cobra.Command{
+	Use:   "backtest",
+	Short: "Simulate risk-ratio sizing over historical candles",
+	Long: `Replays entry signals over OHLCV candles from a CSV file, sizing each trade
+with the riskratio strategy and fee-aware sizing exactly as open does, and
+reports the equity curve, max drawdown, win rate, expectancy and R
+distribution. Runs offline; no config is needed.
+
+Candles: CSV with time, open, high, low, close[, volume], oldest first. Time
+is unix seconds or milliseconds, or a date such as 2024-01-02 15:00:00.
+
+Signals: CSV with time, side[, stop, target], entering at the open of the
+first candle at or after the time; or a built-in rule:
+  sma:<fast>:<slow>   long/short when the fast SMA crosses the slow one
+  breakout:<n>        long/short on a close beyond the prior n-candle range
+
+One trade is open at a time. A candle touching both stop and target counts
+as a stop.
+
+Examples:
+  # SMA 20/50 crossover, stop 2 ATR away, 2:1 target, 1% risk
+  trading-cli backtest --candles eth_1h.csv --rule sma:20:50 --sl atr:2 --risk 1
+
+  # Signals from a file, 1.5% stops, 3:1 targets, fixed sizing
+  trading-cli backtest --candles btc_4h.csv --signals entries.csv --sl 1.5% --rr 3 --compound=false`,
+	Annotations: map[string]string{offlineAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if backtestRisk <= 0 || backtestRisk > 100 {
+			return fmt.Errorf("risk must be between 0 and 100")
+		}
+		if backtestFee < 0 || backtestSlippage < 0 {
+			return fmt.Errorf("fee and slippage must not be negative")
+		}
+		if backtestATRPeriod < 1 {
+			return fmt.Errorf("atr-period must be at least 1")
+		}
+
+		candles, err := backtest.LoadCandles(backtestCandles)
+		if err != nil {
+			return err
+		}
+
+		var signals []backtest.Signal
+		if backtestRule != "" {
+			signals, err = backtest.RuleSignals(backtestRule, candles)
+		} else {
+			signals, err = backtest.LoadSignals(backtestSignals)
+		}
+		if err != nil {
+			return err
+		}
+
+		var stop levels.Expr
+		if backtestSL != "" {
+			if stop, err = backtestLevel(backtestSL, "--sl"); err != nil {
+				return err
+			}
+		} else {
+			for _, signal := range signals {
+				if signal.Stop <= 0 {
+					return fmt.Errorf("signals without a stop need --sl (e.g., --sl 1.5%% or --sl atr:2)")
+				}
+			}
+		}
+		var target *levels.Expr
+		if backtestTP != "" {
+			parsed, err := backtestLevel(backtestTP, "--tp")
+			if err != nil {
+				return err
+			}
+			target = &parsed
+		}
+
+		reg, err := strategies.Lookup(executor.DefaultStrategy)
+		if err != nil {
+			return err
+		}
+		strat, err := reg.Build(map[string]float64{"rr": backtestRR})
+		if err != nil {
+			return err
+		}
+
+		result, err := backtest.Run(cmd.Context(), candles, signals, backtest.Config{
+			Symbol:         backtestSymbol,
+			Strategy:       strat,
+			InitialBalance: backtestBalance,
+			RiskPercent:    backtestRisk,
+			Stop:           stop,
+			Target:         target,
+			ATRPeriod:      backtestATRPeriod,
+			Fees: sizing.Fees{
+				EntryRate:    backtestFee / 100,
+				ExitRate:     backtestFee / 100,
+				SlippageRate: backtestSlippage / 100,
+			},
+			Compound: backtestCompound,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(ui.FormatBacktestReport(result))
+		return nil
+	},
+}
+
+// backtestLevel parses a stop or target relative to each entry; absolute
+// prices only make sense per signal
+func backtestLevel(value, flag string) (levels.Expr, error) {
+	expr, err := levels.Parse(value)
+	if err != nil {
+		return levels.Expr{}, fmt.Errorf("invalid %s: %w", flag, err)
+	}
+	if expr.Kind == levels.KindMark {
+		return levels.Expr{}, fmt.Errorf("invalid %s: mark applies to entries", flag)
+	}
+	if expr.Kind == levels.KindPrice {
+		return levels.Expr{Kind: levels.KindDistance, Value: expr.Value}, nil
+	}
+	return expr, nil
+}
+
+func init() {
+	backtestCmd.Flags().StringVar(&backtestCandles, "candles", "", "OHLCV CSV file (required)")
+	backtestCmd.Flags().StringVar(&backtestSignals, "signals", "", "Signal CSV file: time, side[, stop, target]")
+	backtestCmd.Flags().StringVar(&backtestRule, "rule", "", "Built-in signal rule: sma:<fast>:<slow> or breakout:<n>")
+	backtestCmd.Flags().StringVar(&backtestSymbol, "symbol", "", "Symbol label, for precision (optional)")
+	backtestCmd.Flags().Float64Var(&backtestBalance, "balance", 10000, "Starting balance")
+	backtestCmd.Flags().Float64Var(&backtestRisk, "risk", 1, "Risk percentage per trade")
+	backtestCmd.Flags().Float64Var(&backtestRR, "rr", 2.0, "Risk-reward ratio for targets")
+	backtestCmd.Flags().StringVar(&backtestSL, "sl", "", "Stop distance from entry: price distance, percent (1.5%) or atr:<multiple>")
+	backtestCmd.Flags().StringVar(&backtestTP, "tp", "", "Target distance from entry (optional, overrides RR)")
+	backtestCmd.Flags().IntVar(&backtestATRPeriod, "atr-period", 14, "ATR period for atr: levels")
+	backtestCmd.Flags().Float64Var(&backtestFee, "fee", 0.05, "Taker fee percent per fill")
+	backtestCmd.Flags().Float64Var(&backtestSlippage, "slippage", 0, "Stop slippage percent")
+	backtestCmd.Flags().BoolVar(&backtestCompound, "compound", true, "Size from current equity (false: from the starting balance)")
+
+	backtestCmd.MarkFlagRequired("candles")
+	backtestCmd.MarkFlagsOneRequired("signals", "rule")
+	backtestCmd.MarkFlagsMutuallyExclusive("signals", "rule")
+}
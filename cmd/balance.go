@@ -9,12 +9,16 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/agatticelli/trading-cli/internal/history"
 	"github.com/spf13/cobra"
 )
 
 var (
 	balanceWatch   bool
 	balanceRefresh int
+	balanceHistory bool
+	balanceSince   string
+	balanceUntil   string
 )
 
 var balanceCmd = &cobra.Command{
@@ -22,10 +26,30 @@ var balanceCmd = &cobra.Command{
 	Short: "View account balances",
 	Long: `Displays balance information for all enabled accounts
 
-Use --watch to continuously refresh the display at specified intervals.`,
+Use --watch to continuously refresh the display at specified intervals.
+
+Every run records an equity snapshot per account (at most one every 5
+minutes, including in watch mode). --history draws the equity curve from
+those snapshots, with the max drawdown marked and returns over 24h, 7d,
+30d and 90d.
+
+Examples:
+  # Equity curve over the last 30 days
+  trading-cli balance --history
+
+  # Since the start of the year
+  trading-cli balance --history --since 2024-01-01`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		exec := getExecutor()
 
+		if balanceHistory {
+			period, err := history.ParsePeriod(balanceSince, balanceUntil, time.Now())
+			if err != nil {
+				return err
+			}
+			return exec.ExecuteBalanceHistory(cmd.Context(), period)
+		}
+
 		if !balanceWatch {
 			// Single execution
 			return exec.ExecuteGetBalance(cmd.Context())
@@ -77,6 +101,11 @@ Use --watch to continuously refresh the display at specified intervals.`,
 func init() {
 	balanceCmd.Flags().BoolVarP(&balanceWatch, "watch", "w", false, "Continuously refresh display")
 	balanceCmd.Flags().IntVarP(&balanceRefresh, "refresh", "r", 30, "Refresh interval in seconds (default: 30)")
+	balanceCmd.Flags().BoolVar(&balanceHistory, "history", false, "Show the equity curve from recorded snapshots")
+	balanceCmd.Flags().StringVar(&balanceSince, "since", "30d", "Start of the --history period: date, timestamp, today or a look-back (30d)")
+	balanceCmd.Flags().StringVar(&balanceUntil, "until", "", "End of the --history period (default: now)")
+
+	balanceCmd.MarkFlagsMutuallyExclusive("history", "watch")
 }
 
 // captureBalanceOutput captures stdout from a function
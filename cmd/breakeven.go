@@ -31,6 +31,12 @@ Examples:
 			return fmt.Errorf("symbol is required")
 		}
 
+		resolved, err := exec.ResolveSymbol(cmd.Context(), breakevenSymbol)
+		if err != nil {
+			return err
+		}
+		breakevenSymbol = resolved
+
 		return exec.ExecuteBreakEven(cmd.Context(), breakevenSymbol)
 	},
 }
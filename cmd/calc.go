@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agatticelli/strategy-go"
+	"github.com/agatticelli/trading-cli/internal/executor"
+	"github.com/agatticelli/trading-cli/internal/levels"
+	"github.com/agatticelli/trading-cli/internal/sizing"
+	"github.com/spf13/cobra"
+)
+
+var (
+	calcBalance  float64
+	calcSymbol   string
+	calcSide     string
+	calcEntry    float64
+	calcSL       string
+	calcTP       string
+	calcRisk     float64
+	calcRR       float64
+	calcMMR      float64
+	calcStrategy string
+)
+
+var calcCmd = &cobra.Command{
+	Use:   "calc",
+	Short: "Calculate a position plan offline",
+	Long: `Prints the position plan open would build - size, leverage, notional,
+risk, take profits and liquidation estimates - without a config file or
+network access. Pass --account instead of --balance to size from an
+account's balance (this loads the config and fetches it), and --strategy
+to use a named strategy from the config instead of the default 2:1 --rr.
+
+Examples:
+  # 1% of $10,000, long ETH at 3950 with a stop at 3900, 2:1 target
+  trading-cli calc --balance 10000 --side long --entry 3950 --sl 3900 --risk 1
+
+  # Stop 1.5% from entry, 3:1 target
+  trading-cli calc --balance 10000 --side short --entry 3950 --sl 1.5% --risk 1 --rr 3
+
+  # Size from the main account's balance
+  trading-cli calc --account main --symbol ETH-USDT --side long --entry 3950 --sl 3900 --risk 1
+
+  # Targets from the config's scalp strategy
+  trading-cli calc --balance 10000 --side long --entry 3950 --sl 3900 --risk 1 --strategy scalp`,
+	Annotations: map[string]string{offlineAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var side strategy.Side
+		switch strings.ToLower(calcSide) {
+		case "long", "largo":
+			side = strategy.SideLong
+		case "short", "corto":
+			side = strategy.SideShort
+		default:
+			return fmt.Errorf("invalid side: %s (use 'long' or 'short')", calcSide)
+		}
+		if calcEntry <= 0 {
+			return fmt.Errorf("entry must be positive")
+		}
+		if calcRisk <= 0 || calcRisk > 100 {
+			return fmt.Errorf("risk must be between 0 and 100")
+		}
+		if calcMMR < 0 || calcMMR >= 50 {
+			return fmt.Errorf("mmr must be between 0 and 50")
+		}
+
+		// Stops and targets may be prices, distances or percentages of
+		// the entry; mark and ATR need market data
+		stop, err := calcLevel(calcSL, "--sl")
+		if err != nil {
+			return err
+		}
+		stopPrice, err := stop.Stop(side, calcEntry, 0)
+		if err != nil {
+			return fmt.Errorf("invalid --sl: %w", err)
+		}
+		var targetPrice float64
+		if calcTP != "" {
+			target, err := calcLevel(calcTP, "--tp")
+			if err != nil {
+				return err
+			}
+			if targetPrice, err = target.Target(side, calcEntry, 0); err != nil {
+				return fmt.Errorf("invalid --tp: %w", err)
+			}
+		}
+
+		balance, base := calcBalance, sizing.BaseAvailable
+		if balance <= 0 && len(accounts) != 1 {
+			return fmt.Errorf("pass --balance, or --account with one account to fetch it from")
+		}
+		if balance <= 0 || calcStrategy != "" {
+			if err := initExecutor(); err != nil {
+				return err
+			}
+		}
+
+		params := executor.CalcParams{
+			Side:            side,
+			Entry:           calcEntry,
+			StopLoss:        stopPrice,
+			TakeProfit:      targetPrice,
+			RiskPercent:     calcRisk,
+			RR:              calcRR,
+			MaintenanceRate: calcMMR / 100,
+		}
+		if calcStrategy != "" {
+			if params.Strategy, params.StrategyLabel, err = exec.CalcStrategy(calcStrategy); err != nil {
+				return err
+			}
+		}
+
+		if balance <= 0 {
+			if calcSymbol != "" {
+				if calcSymbol, err = exec.ResolveSymbol(cmd.Context(), calcSymbol); err != nil {
+					return err
+				}
+			}
+			if balance, base, err = exec.SizingCapital(cmd.Context(), accounts[0]); err != nil {
+				return err
+			}
+		}
+		params.Symbol, params.Balance, params.Base = calcSymbol, balance, base
+
+		return executor.Calculate(cmd.Context(), params)
+	},
+}
+
+// calcLevel parses a stop or target that resolves without market data
+func calcLevel(value, flag string) (levels.Expr, error) {
+	expr, err := levels.Parse(value)
+	if err != nil {
+		return levels.Expr{}, fmt.Errorf("invalid %s: %w", flag, err)
+	}
+	if expr.Kind == levels.KindMark || expr.NeedsATR() {
+		return levels.Expr{}, fmt.Errorf("invalid %s: %s needs market data; calc works offline", flag, expr)
+	}
+	return expr, nil
+}
+
+func init() {
+	calcCmd.Flags().Float64Var(&calcBalance, "balance", 0, "Balance to size against (or use --account)")
+	calcCmd.Flags().StringVar(&calcSymbol, "symbol", "", "Symbol, for price precision (optional)")
+	calcCmd.Flags().StringVar(&calcSide, "side", "", "Position side: long or short")
+	calcCmd.Flags().Float64Var(&calcEntry, "entry", 0, "Entry price")
+	calcCmd.Flags().StringVar(&calcSL, "sl", "", "Stop loss price, distance (25) or percent from entry (e.g., 1.5%)")
+	calcCmd.Flags().StringVar(&calcTP, "tp", "", "Take profit price, distance (50) or percent (optional, overrides RR)")
+	calcCmd.Flags().Float64Var(&calcRisk, "risk", 0, "Risk percentage (e.g., 2 for 2%)")
+	calcCmd.Flags().Float64Var(&calcRR, "rr", 2.0, "Risk-reward ratio (e.g., 2 for 2:1)")
+	calcCmd.Flags().Float64Var(&calcMMR, "mmr", 0, "Maintenance margin percent for liquidation (default 0.5)")
+	calcCmd.Flags().StringVar(&calcStrategy, "strategy", "", "Named strategy from the config (instead of --rr)")
+
+	calcCmd.MarkFlagRequired("side")
+	calcCmd.MarkFlagRequired("entry")
+	calcCmd.MarkFlagRequired("sl")
+	calcCmd.MarkFlagRequired("risk")
+	calcCmd.MarkFlagsMutuallyExclusive("rr", "strategy")
+}
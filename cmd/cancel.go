@@ -21,7 +21,13 @@ Examples:
   trading-cli --demo cancel`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		exec := getExecutor()
-		return exec.ExecuteCancelOrders(cmd.Context(), cancelSymbol)
+
+		symbol, err := exec.ResolveSymbol(cmd.Context(), cancelSymbol)
+		if err != nil {
+			return err
+		}
+
+		return exec.ExecuteCancelOrders(cmd.Context(), symbol)
 	},
 }
 
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/agatticelli/intent-go"
 	"github.com/agatticelli/intent-go/witai"
 	"github.com/agatticelli/trading-cli/internal/executor"
+	"github.com/agatticelli/trading-cli/internal/sizing"
 	"github.com/agatticelli/trading-cli/internal/ui"
 	"github.com/chzyer/readline"
 	"github.com/spf13/cobra"
@@ -24,6 +27,8 @@ Powered by Wit.ai, understands trading commands in English and Spanish.
 
 Examples of commands:
   > open long ETH at 3950 with stop loss 3900 and risk 2%
+  > open long ETH at 3950 with stop loss 3900 and risk 50 dollars
+  > open long ETH at 3950 with stop loss 3900 and risk 1% using scalp
   > show my positions
   > close my ETH position
   > set trailing stop on BTC at 51000 with 0.5% callback
@@ -102,7 +107,7 @@ Requires WIT_AI_TOKEN environment variable.`,
 			}
 
 			// Execute based on intent
-			if err := executeNLPCommand(cmd.Context(), exec, command); err != nil {
+			if err := executeNLPCommand(cmd.Context(), exec, command, chatOpenOptions(exec, input)); err != nil {
 				fmt.Println(ui.Error(fmt.Sprintf("Execution failed: %v", err)))
 			}
 
@@ -113,8 +118,74 @@ Requires WIT_AI_TOKEN environment variable.`,
 	},
 }
 
-func executeNLPCommand(ctx context.Context, exec *executor.Executor, cmd *intent.NormalizedCommand) error {
-	// Validate command
+// Sizing phrases the NLP processor doesn't extract
+var (
+	// "risk 50 dollars", "risk $50", "arriesgar 50 dólares"
+	chatRiskUSDPattern = regexp.MustCompile(`(?i)\b(?:risk(?:ing)?|riesgo|arriesgar|arriesgando)\s+(?:\$\s*(\d+(?:\.\d+)?)|(\d+(?:\.\d+)?)\s*(?:\$|(?:dollars?|usdt?|usdc|bucks|d[oó]lares)\b))`)
+	// "notional 5000", "5000 dollars notional"
+	chatNotionalPattern = regexp.MustCompile(`(?i)\bnotional\s+(?:of\s+)?\$?\s*(\d+(?:\.\d+)?)|\$?(\d+(?:\.\d+)?)\s*(?:dollars?|usdt?|usdc)?\s+notional\b`)
+	// "qty 0.5", "quantity 2", "cantidad 2"
+	chatQuantityPattern = regexp.MustCompile(`(?i)\b(?:qty|quantity|cantidad)\s+(\d+(?:\.\d+)?)`)
+)
+
+// Strategy phrases: "strategy scalp" always names a strategy, while
+// "using scalp" only counts when scalp is configured ("using 2% risk")
+var (
+	chatStrategyPattern = regexp.MustCompile(`(?i)\b(?:strategy|estrategia)\s+([\w-]+)`)
+	chatUsingPattern    = regexp.MustCompile(`(?i)\b(?:using|usando)\s+([\w-]+)`)
+)
+
+// chatOpenOptions extracts open options the NLP processor doesn't cover
+// from the raw chat input
+func chatOpenOptions(exec *executor.Executor, input string) executor.OpenOptions {
+	opts := executor.OpenOptions{Strategy: executor.DefaultStrategy}
+
+	if match := chatStrategyPattern.FindStringSubmatch(input); match != nil {
+		opts.Strategy = match[1]
+	} else if match := chatUsingPattern.FindStringSubmatch(input); match != nil && exec.HasStrategy(match[1]) {
+		opts.Strategy = match[1]
+	}
+
+	patterns := []struct {
+		pattern *regexp.Regexp
+		model   sizing.Model
+	}{
+		{chatRiskUSDPattern, sizing.ModelRiskUSD},
+		{chatNotionalPattern, sizing.ModelNotional},
+		{chatQuantityPattern, sizing.ModelQuantity},
+	}
+	for _, p := range patterns {
+		if value, ok := firstNumber(p.pattern.FindStringSubmatch(input)); ok {
+			opts.Sizing = sizing.Request{Model: p.model, Value: value}
+			break
+		}
+	}
+
+	return opts
+}
+
+// firstNumber parses the first non-empty capture group of a match
+func firstNumber(match []string) (float64, bool) {
+	for _, group := range match[min(1, len(match)):] {
+		if group == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(group, 64)
+		return value, err == nil && value > 0
+	}
+	return 0, false
+}
+
+func executeNLPCommand(ctx context.Context, exec *executor.Executor, cmd *intent.NormalizedCommand, opts executor.OpenOptions) error {
+	// Validate command. A dollar, notional or quantity size stands in for
+	// the risk percent, which the NLP processor may report missing or
+	// misread ("risk 50 dollars" as 50%).
+	if opts.Sizing.Model != "" {
+		cmd.RiskPercent = nil
+		cmd.Missing = withoutRiskPercent(cmd.Missing)
+		cmd.Errors = withoutRiskPercent(cmd.Errors)
+		cmd.Valid = len(cmd.Missing) == 0 && len(cmd.Errors) == 0
+	}
 	if !cmd.Valid {
 		if len(cmd.Missing) > 0 {
 			return fmt.Errorf("missing parameters: %v", cmd.Missing)
@@ -124,10 +195,17 @@ func executeNLPCommand(ctx context.Context, exec *executor.Executor, cmd *intent
 		}
 	}
 
+	// Chat users type "eth", "ether" or "ETHUSDT"
+	symbol, err := exec.ResolveSymbol(ctx, cmd.Symbol)
+	if err != nil {
+		return err
+	}
+	cmd.Symbol = symbol
+
 	// Execute based on intent
 	switch cmd.Intent {
 	case intent.IntentOpenPosition:
-		return exec.ExecuteOpenPosition(ctx, cmd, "riskratio")
+		return exec.ExecuteOpenPosition(ctx, cmd, opts)
 
 	case intent.IntentClosePosition:
 		symbol := cmd.Symbol
@@ -159,3 +237,18 @@ func executeNLPCommand(ctx context.Context, exec *executor.Executor, cmd *intent
 		return fmt.Errorf("unknown intent: %s", cmd.Intent)
 	}
 }
+
+// riskPercentKey is the parameter intent-go reports for the risk percent
+const riskPercentKey = "risk_percent"
+
+// withoutRiskPercent drops the risk percent's missing key and errors that
+// name it, keeping everything else (a stop loss missing, say)
+func withoutRiskPercent(entries []string) []string {
+	kept := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry, riskPercentKey) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
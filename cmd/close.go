@@ -33,6 +33,12 @@ Examples:
 			return fmt.Errorf("percentage must be between 0 and 100")
 		}
 
+		resolved, err := exec.ResolveSymbol(cmd.Context(), closeSymbol)
+		if err != nil {
+			return err
+		}
+		closeSymbol = resolved
+
 		return exec.ExecuteClosePosition(cmd.Context(), closeSymbol, closePercentage)
 	},
 }
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/executor"
+	"github.com/agatticelli/trading-cli/internal/history"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historySymbol string
+	historySince  string
+	historyUntil  string
+	historyJSON   bool
+	historyCSV    bool
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show closed trades with fees, realized PnL and R",
+	Long: `Pulls fills from every account for a period and pairs them into round
+trips: a trade opens when a position leaves flat and closes when it is flat
+again. Shows entry and exit (averaged over partial fills), size, holding
+time, fees, net realized PnL and the R multiple.
+
+R values come from the local trade journal written by 'open'; trades opened
+elsewhere show no R.
+
+--since and --until take a date (2024-01-02), an RFC 3339 timestamp, "today"
+or a look-back such as 24h, 7d or 4w.
+
+Examples:
+  # Last 7 days on every account
+  trading-cli history
+
+  # ETH trades in January
+  trading-cli history --symbol ETH-USDT --since 2024-01-01 --until 2024-02-01
+
+  # Export the last 30 days
+  trading-cli history --since 30d --csv > trades.csv
+  trading-cli history --since 30d --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exec := getExecutor()
+
+		period, err := history.ParsePeriod(historySince, historyUntil, time.Now())
+		if err != nil {
+			return err
+		}
+
+		symbol, err := exec.ResolveSymbol(cmd.Context(), historySymbol)
+		if err != nil {
+			return err
+		}
+
+		return exec.ExecuteHistory(cmd.Context(), executor.HistoryOptions{
+			Symbol: symbol,
+			Period: period,
+			Format: exportFormat(historyJSON, historyCSV),
+		})
+	},
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historySymbol, "symbol", "", "Only trades on this symbol")
+	historyCmd.Flags().StringVar(&historySince, "since", "7d", "Start of the period: date, timestamp, today or a look-back (7d)")
+	historyCmd.Flags().StringVar(&historyUntil, "until", "", "End of the period (default: now)")
+	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "Output trades as JSON")
+	historyCmd.Flags().BoolVar(&historyCSV, "csv", false, "Output trades as CSV")
+
+	historyCmd.MarkFlagsMutuallyExclusive("json", "csv")
+}
+
+// exportFormat maps the --json and --csv flags to an executor format
+func exportFormat(asJSON, asCSV bool) string {
+	switch {
+	case asJSON:
+		return executor.FormatJSON
+	case asCSV:
+		return executor.FormatCSV
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	marginSymbol string
+	marginAdd    float64
+	marginRemove float64
+)
+
+var marginCmd = &cobra.Command{
+	Use:   "margin",
+	Short: "Add or remove isolated margin on a position",
+	Long: `Moves margin into or out of an isolated position, pushing its
+liquidation price further away (add) or freeing balance (remove).
+
+Examples:
+  # Add $100 margin to the ETH position
+  trading-cli --demo margin --symbol ETH-USDT --add 100
+
+  # Remove $50 margin from the BTC position
+  trading-cli --demo margin --symbol BTC-USDT --remove 50`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exec := getExecutor()
+
+		if marginSymbol == "" {
+			return fmt.Errorf("symbol is required")
+		}
+		if marginAdd < 0 || marginRemove < 0 {
+			return fmt.Errorf("amount must be positive")
+		}
+		if (marginAdd > 0) == (marginRemove > 0) {
+			return fmt.Errorf("specify exactly one of --add or --remove")
+		}
+
+		resolved, err := exec.ResolveSymbol(cmd.Context(), marginSymbol)
+		if err != nil {
+			return err
+		}
+
+		amount := marginAdd
+		if marginRemove > 0 {
+			amount = -marginRemove
+		}
+
+		return exec.ExecuteAdjustMargin(cmd.Context(), resolved, amount)
+	},
+}
+
+func init() {
+	marginCmd.Flags().StringVar(&marginSymbol, "symbol", "", "Trading symbol (required)")
+	marginCmd.Flags().Float64Var(&marginAdd, "add", 0, "USDT margin to add")
+	marginCmd.Flags().Float64Var(&marginRemove, "remove", 0, "USDT margin to remove")
+	marginCmd.MarkFlagRequired("symbol")
+}
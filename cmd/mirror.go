@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/config"
+	"github.com/agatticelli/trading-cli/internal/executor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mirrorLeader     string
+	mirrorFollowers  []string
+	mirrorMultiplier float64
+	mirrorInterval   time.Duration
+	mirrorDrift      float64
+	mirrorOnce       bool
+	mirrorDryRun     bool
+)
+
+var mirrorCmd = &cobra.Command{
+	Use:   "mirror",
+	Short: "Copy a leader account's positions to follower accounts",
+	Long: `Runs a copy trading daemon. Every interval the leader's positions, stops
+and take profits are compared with each follower's, and the follower is
+brought back in line: new positions are opened at market, closed ones are
+closed, partial closes and adds are replayed, and SL/TP orders are replaced
+whenever the leader's prices change.
+
+Follower sizes are the leader's scaled by the follower's equity over the
+leader's, or by a fixed multiplier. Sizes are rounded to the follower's lot
+step; a size more than --drift percent off target is corrected, smaller
+drift is tolerated so moving equity doesn't cause churn.
+
+The first pass prints a reconciliation report per follower; later passes
+only log the orders placed. Defaults come from the 'mirror' section of the
+config; flags override them.
+
+Opens and adds respect each follower's daily loss lockout and risk limits
+and are recorded in the trade journal; a blocked follower is still reduced,
+closed and protected along with the leader.
+
+Pending leader entry orders (limits, stops) are not copied until they fill.
+Followers should not be traded by hand while mirrored: anything that
+doesn't match the leader is undone.
+
+Examples:
+  # Leader and followers from the config
+  trading-cli mirror
+
+  # Preview what would be placed, without trading
+  trading-cli mirror --dry-run
+
+  # Explicit accounts: one by equity ratio, one at half the leader's size
+  trading-cli mirror --leader main --follower alt --follower small:0.5
+
+  # Reconcile once (e.g. from cron) and exit
+  trading-cli mirror --once`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exec := getExecutor()
+
+		opts := executor.MirrorOptions{
+			Leader:       cfg.Mirror.Leader,
+			Followers:    cfg.Mirror.Followers,
+			Interval:     cfg.Mirror.PollInterval(),
+			DriftPercent: cfg.Mirror.DriftPercent,
+			Once:         mirrorOnce,
+			DryRun:       mirrorDryRun,
+		}
+		if mirrorLeader != "" {
+			opts.Leader = mirrorLeader
+		}
+		if len(mirrorFollowers) > 0 {
+			followers, err := parseFollowers(mirrorFollowers)
+			if err != nil {
+				return err
+			}
+			opts.Followers = followers
+		}
+		if mirrorMultiplier < 0 {
+			return fmt.Errorf("multiplier must not be negative")
+		}
+		if mirrorMultiplier > 0 {
+			// Applies to followers without their own multiplier
+			followers := make([]config.MirrorFollower, len(opts.Followers))
+			for i, follower := range opts.Followers {
+				if follower.Multiplier == 0 {
+					follower.Multiplier = mirrorMultiplier
+				}
+				followers[i] = follower
+			}
+			opts.Followers = followers
+		}
+		if cmd.Flags().Changed("interval") {
+			if mirrorInterval < time.Second {
+				return fmt.Errorf("interval must be at least 1s")
+			}
+			opts.Interval = mirrorInterval
+		}
+		if cmd.Flags().Changed("drift") {
+			if mirrorDrift <= 0 || mirrorDrift >= 100 {
+				return fmt.Errorf("drift must be between 0 and 100")
+			}
+			opts.DriftPercent = mirrorDrift
+		}
+		if opts.Leader == "" {
+			return fmt.Errorf("no leader account: set mirror.leader in the config or use --leader")
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		return exec.ExecuteMirror(ctx, opts)
+	},
+}
+
+func init() {
+	mirrorCmd.Flags().StringVar(&mirrorLeader, "leader", "", "Account to copy (default: mirror.leader)")
+	mirrorCmd.Flags().StringSliceVar(&mirrorFollowers, "follower", nil, "Follower account, optionally name:multiplier (repeatable; default: mirror.followers)")
+	mirrorCmd.Flags().Float64Var(&mirrorMultiplier, "multiplier", 0, "Size ratio for followers without their own (default: equity ratio)")
+	mirrorCmd.Flags().DurationVar(&mirrorInterval, "interval", 10*time.Second, "Poll interval")
+	mirrorCmd.Flags().Float64Var(&mirrorDrift, "drift", 5, "Size drift in percent tolerated before resizing")
+	mirrorCmd.Flags().BoolVar(&mirrorOnce, "once", false, "Reconcile once and exit")
+	mirrorCmd.Flags().BoolVar(&mirrorDryRun, "dry-run", false, "Print the reconciliation report without placing orders")
+}
+
+// parseFollowers parses --follower values of the form name or
+// name:multiplier
+func parseFollowers(values []string) ([]config.MirrorFollower, error) {
+	followers := make([]config.MirrorFollower, 0, len(values))
+	for _, value := range values {
+		name, multiplier, hasMultiplier := strings.Cut(value, ":")
+		follower := config.MirrorFollower{Account: strings.TrimSpace(name)}
+		if follower.Account == "" {
+			return nil, fmt.Errorf("invalid follower %q", value)
+		}
+		if hasMultiplier {
+			m, err := strconv.ParseFloat(multiplier, 64)
+			if err != nil || m <= 0 {
+				return nil, fmt.Errorf("invalid multiplier in follower %q", value)
+			}
+			follower.Multiplier = m
+		}
+		followers = append(followers, follower)
+	}
+	return followers, nil
+}
@@ -4,17 +4,33 @@ import (
 	"fmt"
 
 	"github.com/agatticelli/intent-go"
+	"github.com/agatticelli/trading-cli/internal/executor"
+	"github.com/agatticelli/trading-cli/internal/levels"
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-cli/internal/sizing"
 	"github.com/spf13/cobra"
 )
 
 var (
-	openSymbol  string
-	openSide    string
-	openEntry   float64
-	openSL      float64
-	openRisk    float64
-	openRR      float64
-	openTP      float64
+	openSymbol   string
+	openSide     string
+	openEntry    string
+	openSL       string
+	openRisk     float64
+	openRiskUSD  float64
+	openNotional float64
+	openQty      float64
+	openRR       float64
+	openTP       string
+	openOverride bool
+	openRetry    bool
+	openMargin   string
+	openBase     string
+	openStrategy string
+	openAllocate string
+
+	openATRPeriod   int
+	openATRInterval string
 )
 
 var openCmd = &cobra.Command{
@@ -22,20 +38,76 @@ var openCmd = &cobra.Command{
 	Short: "Open a new position",
 	Long: `Opens a new trading position with risk-based position sizing.
 
+Each account is sized from its own balance. With --allocate the size is
+computed once for all accounts together (--risk is then a percent of their
+combined balance) and split by equity share (--allocate equity) or by
+weights (--allocate main=2,alt=1); an account whose share is below the
+exchange minimums is left out and its share goes to the others. The
+allocation table is shown before any order is placed.
+
 Examples:
   # Open long position with 2% risk and 2:1 RR
   trading-cli --demo open --symbol ETH-USDT --side long --entry 3950 --sl 3900 --risk 2 --rr 2
 
   # Open short position with specific TP
-  trading-cli --demo open --symbol BTC-USDT --side short --entry 50000 --sl 51000 --tp 48000 --risk 1`,
+  trading-cli --demo open --symbol BTC-USDT --side short --entry 50000 --sl 51000 --tp 48000 --risk 1
+
+  # Size by dollars at risk, position value, or quantity instead of percent
+  trading-cli --demo open --symbol ETH-USDT --side long --entry 3950 --sl 3900 --risk-usd 50
+  trading-cli --demo open --symbol ETH-USDT --side long --entry 3950 --sl 3900 --notional 5000
+  trading-cli --demo open --symbol ETH-USDT --side long --entry 3950 --sl 3900 --qty 1.5
+
+  # Bypass the daily loss lockout (recorded in the override log)
+  trading-cli open --symbol ETH-USDT --side long --entry 3950 --sl 3900 --risk 1 --override
+
+  # Open on isolated margin regardless of the account's current mode
+  trading-cli open --symbol ETH-USDT --side long --entry 3950 --sl 3900 --risk 1 --margin isolated
+
+  # Take the 1% from total equity, or from a fixed $10,000 of capital
+  trading-cli open --symbol ETH-USDT --side long --entry 3950 --sl 3900 --risk 1 --base equity
+  trading-cli open --symbol ETH-USDT --side long --entry 3950 --sl 3900 --risk 1 --base 10000
+
+  # Enter 0.3% below mark with a stop 1.5% below the entry
+  trading-cli --demo open --symbol ETH-USDT --side long --entry -0.3% --sl 1.5% --risk 1
+
+  # Stop 1.5 ATR and target 3 ATR from the entry (ATR(14) on 1h candles)
+  trading-cli --demo open --symbol ETH-USDT --side long --entry 3950 --sl atr:1.5 --tp atr:3 --risk 1
+
+  # Use a named strategy from the config (see "trading-cli strategies")
+  trading-cli open --symbol ETH-USDT --side long --entry 3950 --sl 3900 --risk 1 --strategy scalp
+
+  # Risk $300 in total, split across accounts by equity share
+  trading-cli open --symbol ETH-USDT --side long --entry 3950 --sl 3900 --risk-usd 300 --allocate equity
+
+  # Split 2:1 between two accounts
+  trading-cli open --symbol ETH-USDT --side long --entry 3950 --sl 3900 --risk-usd 300 --allocate main=2,alt=1`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		exec := getExecutor()
 
+		// Parse entry, stop and target: absolute prices go into the
+		// command, expressions are resolved per account
+		prices, err := parseOpenLevels()
+		if err != nil {
+			return err
+		}
+		if openATRPeriod < 0 {
+			return fmt.Errorf("atr-period must be positive")
+		}
+
 		// Build NormalizedCommand from flags
-		command, err := buildNormalizedCommand()
+		command, err := buildNormalizedCommand(prices)
 		if err != nil {
 			return fmt.Errorf("invalid parameters: %w", err)
 		}
+		if cmd.Flags().Changed("rr") {
+			command.RRRatio = &openRR
+		}
+
+		command.Symbol, err = exec.ResolveSymbol(cmd.Context(), command.Symbol)
+		if err != nil {
+			return err
+		}
 
 		// Validate command
 		if !command.Valid {
@@ -47,35 +119,148 @@ Examples:
 			}
 		}
 
-		// Execute with default riskratio strategy
-		return exec.ExecuteOpenPosition(cmd.Context(), command, "riskratio")
+		var marginMode market.MarginMode
+		if openMargin != "" {
+			marginMode, err = market.ParseMarginMode(openMargin)
+			if err != nil {
+				return err
+			}
+		}
+
+		return exec.ExecuteOpenPosition(cmd.Context(), command, executor.OpenOptions{
+			Strategy:        openStrategy,
+			Override:        openOverride,
+			RetryProtection: openRetry,
+			MarginMode:      marginMode,
+			Sizing:          openSizingRequest(),
+			SizingBase:      openBase,
+			Entry:           prices.relativeEntry(),
+			StopLoss:        prices.relativeExit(&prices.Stop),
+			TakeProfit:      prices.relativeExit(prices.Target),
+			ATRPeriod:       openATRPeriod,
+			ATRInterval:     openATRInterval,
+			Allocate:        openAllocate,
+		})
 	},
 }
 
 func init() {
 	openCmd.Flags().StringVar(&openSymbol, "symbol", "", "Trading symbol (e.g., ETH-USDT)")
 	openCmd.Flags().StringVar(&openSide, "side", "", "Position side: long or short")
-	openCmd.Flags().Float64Var(&openEntry, "entry", 0, "Entry price")
-	openCmd.Flags().Float64Var(&openSL, "sl", 0, "Stop loss price")
+	openCmd.Flags().StringVar(&openEntry, "entry", "", "Entry price, mark, or a percent from mark (e.g., -0.3%)")
+	openCmd.Flags().StringVar(&openSL, "sl", "", "Stop loss price, distance (25), percent (1.5%) or atr:<multiple> from entry")
 	openCmd.Flags().Float64Var(&openRisk, "risk", 0, "Risk percentage (e.g., 2 for 2%)")
+	openCmd.Flags().Float64Var(&openRiskUSD, "risk-usd", 0, "Risk a fixed dollar amount instead of a percentage")
+	openCmd.Flags().Float64Var(&openNotional, "notional", 0, "Size by position value in dollars")
+	openCmd.Flags().Float64Var(&openQty, "qty", 0, "Size by quantity in base units (e.g., 0.5 ETH)")
 	openCmd.Flags().Float64Var(&openRR, "rr", 2.0, "Risk-reward ratio (e.g., 2 for 2:1)")
-	openCmd.Flags().Float64Var(&openTP, "tp", 0, "Take profit price (optional, overrides RR)")
+	openCmd.Flags().StringVar(&openTP, "tp", "", "Take profit price or atr:<multiple> (optional, overrides RR)")
+	openCmd.Flags().IntVar(&openATRPeriod, "atr-period", 0, "ATR period for atr: levels (default: config, else 14)")
+	openCmd.Flags().StringVar(&openATRInterval, "atr-interval", "", "Candle interval for atr: levels (default: config, else 1h)")
+	openCmd.Flags().BoolVar(&openOverride, "override", false, "Bypass the daily loss lockout (recorded)")
+	openCmd.Flags().BoolVar(&openRetry, "retry-protection", false, "Re-attach SL/TP if missing after placement")
+	openCmd.Flags().StringVar(&openMargin, "margin", "", "Margin mode: isolated or cross (default: account setting)")
+	openCmd.Flags().StringVar(&openStrategy, "strategy", "", "Named strategy from the config (instead of --rr)")
+	openCmd.Flags().StringVar(&openBase, "base", "", "Sizing base: available, equity, fixed or a capital amount (default: config)")
+	openCmd.Flags().StringVar(&openAllocate, "allocate", "", "Split one total size across accounts: equity, or weights such as main=2,alt=1")
 
 	openCmd.MarkFlagRequired("symbol")
 	openCmd.MarkFlagRequired("side")
 	openCmd.MarkFlagRequired("entry")
 	openCmd.MarkFlagRequired("sl")
-	openCmd.MarkFlagRequired("risk")
+	openCmd.MarkFlagsOneRequired("risk", "risk-usd", "notional", "qty")
+	openCmd.MarkFlagsMutuallyExclusive("risk", "risk-usd", "notional", "qty")
+	openCmd.MarkFlagsMutuallyExclusive("rr", "strategy")
+}
+
+// openSizingRequest returns the sizing model chosen by flags
+func openSizingRequest() sizing.Request {
+	switch {
+	case openRiskUSD > 0:
+		return sizing.Request{Model: sizing.ModelRiskUSD, Value: openRiskUSD}
+	case openNotional > 0:
+		return sizing.Request{Model: sizing.ModelNotional, Value: openNotional}
+	case openQty > 0:
+		return sizing.Request{Model: sizing.ModelQuantity, Value: openQty}
+	default:
+		return sizing.Request{Model: sizing.ModelRiskPercent, Value: openRisk}
+	}
+}
+
+// openLevels are the parsed --entry, --sl and --tp expressions
+type openLevels struct {
+	Entry  levels.Expr
+	Stop   levels.Expr
+	Target *levels.Expr
 }
 
-func buildNormalizedCommand() (*intent.NormalizedCommand, error) {
+func parseOpenLevels() (*openLevels, error) {
+	var prices openLevels
+	var err error
+
+	if prices.Entry, err = levels.Parse(openEntry); err != nil {
+		return nil, fmt.Errorf("invalid --entry: %w", err)
+	}
+	if prices.Entry.NeedsATR() {
+		return nil, fmt.Errorf("invalid --entry: atr: applies to --sl and --tp")
+	}
+	if prices.Stop, err = levels.Parse(openSL); err != nil {
+		return nil, fmt.Errorf("invalid --sl: %w", err)
+	}
+	if prices.Stop.Kind == levels.KindMark {
+		return nil, fmt.Errorf("invalid --sl: mark applies to --entry")
+	}
+	if openTP != "" {
+		target, err := levels.Parse(openTP)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --tp: %w", err)
+		}
+		if target.Kind == levels.KindMark {
+			return nil, fmt.Errorf("invalid --tp: mark applies to --entry")
+		}
+		prices.Target = &target
+	}
+
+	return &prices, nil
+}
+
+// relativeEntry returns the entry when it depends on the current price
+func (p *openLevels) relativeEntry() *levels.Expr {
+	if !p.Entry.Relative() {
+		return nil
+	}
+	return &p.Entry
+}
+
+// absoluteExit returns a stop or target price known without market data:
+// an absolute price that isn't a distance from an absolute entry
+func (p *openLevels) absoluteExit(expr *levels.Expr) *float64 {
+	if expr == nil || p.Entry.Relative() || expr.Relative() || expr.Distance(p.Entry.Value) {
+		return nil
+	}
+	return &expr.Value
+}
+
+// relativeExit returns a stop or target expression the executor resolves
+// per account, or nil when the command carries the price
+func (p *openLevels) relativeExit(expr *levels.Expr) *levels.Expr {
+	if expr == nil || p.absoluteExit(expr) != nil {
+		return nil
+	}
+	return expr
+}
+
+func buildNormalizedCommand(prices *openLevels) (*intent.NormalizedCommand, error) {
 	cmd := &intent.NormalizedCommand{
-		Intent:      intent.IntentOpenPosition,
-		Symbol:      openSymbol,
-		EntryPrice:  &openEntry,
-		StopLoss:    &openSL,
-		RiskPercent: &openRisk,
-		RRRatio:     &openRR,
+		Intent:   intent.IntentOpenPosition,
+		Symbol:   openSymbol,
+		StopLoss: prices.absoluteExit(&prices.Stop),
+	}
+	if !prices.Entry.Relative() {
+		cmd.EntryPrice = &prices.Entry.Value
+	}
+	if openRisk != 0 {
+		cmd.RiskPercent = &openRisk
 	}
 
 	// Parse side
@@ -91,9 +276,7 @@ func buildNormalizedCommand() (*intent.NormalizedCommand, error) {
 	}
 
 	// If TP specified, use it; otherwise let strategy calculate from RR
-	if openTP > 0 {
-		cmd.TakeProfit = &openTP
-	}
+	cmd.TakeProfit = prices.absoluteExit(prices.Target)
 
 	// Validate
 	cmd.Valid = true
@@ -108,16 +291,12 @@ func buildNormalizedCommand() (*intent.NormalizedCommand, error) {
 		cmd.Missing = append(cmd.Missing, "side")
 		cmd.Valid = false
 	}
-	if cmd.EntryPrice == nil || *cmd.EntryPrice <= 0 {
-		cmd.Missing = append(cmd.Missing, "entry_price")
-		cmd.Valid = false
-	}
-	if cmd.StopLoss == nil || *cmd.StopLoss <= 0 {
-		cmd.Missing = append(cmd.Missing, "stop_loss")
+	if cmd.RiskPercent != nil && (*cmd.RiskPercent <= 0 || *cmd.RiskPercent > 100) {
+		cmd.Errors = append(cmd.Errors, "risk must be between 0 and 100")
 		cmd.Valid = false
 	}
-	if cmd.RiskPercent == nil || *cmd.RiskPercent <= 0 || *cmd.RiskPercent > 100 {
-		cmd.Errors = append(cmd.Errors, "risk must be between 0 and 100")
+	if openRiskUSD < 0 || openNotional < 0 || openQty < 0 {
+		cmd.Errors = append(cmd.Errors, "risk-usd, notional and qty must be positive")
 		cmd.Valid = false
 	}
 
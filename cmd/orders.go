@@ -29,6 +29,12 @@ Use --watch to continuously refresh the display at specified intervals.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		exec := getExecutor()
 
+		resolved, err := exec.ResolveSymbol(cmd.Context(), ordersSymbol)
+		if err != nil {
+			return err
+		}
+		ordersSymbol = resolved
+
 		if !ordersWatch {
 			// Single execution
 			return exec.ExecuteGetOrders(cmd.Context(), ordersSymbol, ordersVerbose)
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	panicTimeout time.Duration
+	panicYes     bool
+)
+
+var panicCmd = &cobra.Command{
+	Use:   "panic",
+	Short: "Flatten everything: cancel all orders and close all positions",
+	Long: `Concurrently cancels every open order and market-closes every position on
+all selected accounts, then re-queries until each account is confirmed flat
+or the timeout expires. Ends with a per-account verification summary.
+
+Examples:
+  # Flatten all enabled accounts (asks for confirmation)
+  trading-cli panic
+
+  # Flatten one account without prompting
+  trading-cli --account main panic --yes
+
+  # Allow more time for fills
+  trading-cli panic --timeout 60s`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exec := getExecutor()
+
+		if panicTimeout <= 0 {
+			return fmt.Errorf("timeout must be positive")
+		}
+
+		if !panicYes {
+			fmt.Printf("This will cancel ALL orders and market-close ALL positions on: %s\n",
+				strings.Join(exec.AccountNames(), ", "))
+			fmt.Print("Type 'flatten' to confirm: ")
+
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if strings.TrimSpace(answer) != "flatten" {
+				return fmt.Errorf("aborted")
+			}
+		}
+
+		return exec.ExecutePanic(cmd.Context(), panicTimeout)
+	},
+}
+
+func init() {
+	panicCmd.Flags().DurationVar(&panicTimeout, "timeout", 30*time.Second, "Max time to wait for positions to be confirmed flat")
+	panicCmd.Flags().BoolVarP(&panicYes, "yes", "y", false, "Skip confirmation prompt")
+}
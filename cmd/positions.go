@@ -27,6 +27,12 @@ Use --watch to continuously refresh the display at specified intervals.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		exec := getExecutor()
 
+		resolved, err := exec.ResolveSymbol(cmd.Context(), positionsSymbol)
+		if err != nil {
+			return err
+		}
+		positionsSymbol = resolved
+
 		if !positionsWatch {
 			// Single execution
 			return exec.ExecuteGetPositions(cmd.Context(), positionsSymbol)
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	riskJSON bool
+)
+
+var riskCmd = &cobra.Command{
+	Use:   "risk",
+	Short: "Show portfolio heat (what you lose if every stop is hit)",
+	Long: `Combines open positions with their stop orders to show, per account and
+in total: $ at risk, % of equity, profit locked in by stops (in $ and R),
+positions without a stop, and long/short exposure.
+
+R values come from the local trade journal written by 'open'.
+
+Examples:
+  # Heat report for all accounts
+  trading-cli risk
+
+  # Machine-readable output
+  trading-cli risk --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exec := getExecutor()
+		return exec.ExecuteRiskReport(cmd.Context(), riskJSON)
+	},
+}
+
+func init() {
+	riskCmd.Flags().BoolVar(&riskJSON, "json", false, "Output report as JSON")
+}
@@ -14,6 +14,7 @@ var (
 	// Global flags
 	configPath string
 	demoMode   bool
+	accounts   []string
 
 	// Global state
 	cfg  *config.Config
@@ -23,10 +24,10 @@ var (
 // rootCmd represents the base command
 var rootCmd = &cobra.Command{
 	Use:   "trading-cli",
-	Short: "Minimalist trading CLI for BingX with natural language support",
+	Short: "Minimalist trading CLI for BingX and Binance futures with natural language support",
 	Long: `A minimalist trading CLI built on modular architecture:
 
-- trading-go: Broker abstraction (BingX, extensible to others)
+- trading-go: Broker abstraction (BingX, plus Binance USDⓈ-M via internal/brokers)
 - strategy-go: Trading strategies and risk management
 - intent-go: NLP intent processing (Wit.ai, OpenAI, etc.)
 
@@ -37,26 +38,42 @@ Features:
 - Advanced orders (TP/SL, trailing stops)
 - Demo mode for safe testing`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		// Skip initialization for help commands
-		if cmd.Name() == "help" || cmd.Parent() == nil {
+		// Skip initialization for help commands and offline commands,
+		// which call initExecutor themselves if they need an account
+		if cmd.Name() == "help" || cmd.Parent() == nil || cmd.Annotations[offlineAnnotation] != "" {
 			return nil
 		}
 
-		// Load configuration
-		var err error
-		cfg, err = config.Load(configPath)
-		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
-		}
+		return initExecutor()
+	},
+}
+
+// offlineAnnotation marks commands that run without config or network
+const offlineAnnotation = "offline"
+
+// initExecutor loads the configuration and connects the selected accounts
+func initExecutor() error {
+	// Load configuration
+	var err error
+	cfg, err = config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize executor
+	exec, err = executor.New(cfg, demoMode)
+	if err != nil {
+		return fmt.Errorf("failed to initialize executor: %w", err)
+	}
 
-		// Initialize executor
-		exec, err = executor.New(cfg, demoMode)
-		if err != nil {
-			return fmt.Errorf("failed to initialize executor: %w", err)
+	// Restrict to selected accounts
+	if len(accounts) > 0 {
+		if err := exec.SelectAccounts(accounts); err != nil {
+			return err
 		}
+	}
 
-		return nil
-	},
+	return nil
 }
 
 // Execute runs the root command
@@ -68,6 +85,7 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&configPath, "config", "configs/accounts.yaml", "Path to configuration file")
 	rootCmd.PersistentFlags().BoolVar(&demoMode, "demo", false, "Enable demo/testnet mode")
+	rootCmd.PersistentFlags().StringSliceVar(&accounts, "account", nil, "Only use these accounts (repeatable or comma-separated; default: all enabled)")
 
 	// Add subcommands
 	rootCmd.AddCommand(balanceCmd)
@@ -79,6 +97,16 @@ func init() {
 	rootCmd.AddCommand(trailCmd)
 	rootCmd.AddCommand(breakevenCmd)
 	rootCmd.AddCommand(chatCmd)
+	rootCmd.AddCommand(riskCmd)
+	rootCmd.AddCommand(panicCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(marginCmd)
+	rootCmd.AddCommand(strategiesCmd)
+	rootCmd.AddCommand(calcCmd)
+	rootCmd.AddCommand(backtestCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(mirrorCmd)
 }
 
 // getExecutor returns the initialized executor or exits
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/executor"
+	"github.com/agatticelli/trading-cli/internal/history"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsSymbol string
+	statsSince  string
+	statsUntil  string
+	statsJSON   bool
+	statsCSV    bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Report trading performance over a period",
+	Long: `Analyzes the closed trades 'history' shows for a period: win rate, average
+win and loss, profit factor, expectancy in $ and R, the longest losing
+streak, and Sharpe and Sortino ratios on daily returns, in total, per
+account and per symbol (best to worst).
+
+Daily returns divide each day's realized PnL by the balance at the start of
+that day, walked back from the current balance; deposits and withdrawals in
+the period skew them.
+
+Examples:
+  # Last week
+  trading-cli stats
+
+  # A given month, one symbol
+  trading-cli stats --since 2024-03-01 --until 2024-04-01 --symbol BTC-USDT
+
+  # Export for a spreadsheet
+  trading-cli stats --since 90d --csv > stats.csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exec := getExecutor()
+
+		period, err := history.ParsePeriod(statsSince, statsUntil, time.Now())
+		if err != nil {
+			return err
+		}
+
+		symbol, err := exec.ResolveSymbol(cmd.Context(), statsSymbol)
+		if err != nil {
+			return err
+		}
+
+		return exec.ExecuteStats(cmd.Context(), executor.StatsOptions{
+			Symbol: symbol,
+			Period: period,
+			Format: exportFormat(statsJSON, statsCSV),
+		})
+	},
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsSymbol, "symbol", "", "Only trades on this symbol")
+	statsCmd.Flags().StringVar(&statsSince, "since", "7d", "Start of the period: date, timestamp, today or a look-back (7d)")
+	statsCmd.Flags().StringVar(&statsUntil, "until", "", "End of the period (default: now)")
+	statsCmd.Flags().BoolVar(&statsJSON, "json", false, "Output the report as JSON")
+	statsCmd.Flags().BoolVar(&statsCSV, "csv", false, "Output the report as CSV")
+
+	statsCmd.MarkFlagsMutuallyExclusive("json", "csv")
+}
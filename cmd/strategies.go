@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var strategiesCmd = &cobra.Command{
+	Use:   "strategies",
+	Short: "List strategies available to open",
+	Long: `Lists the built-in riskratio strategy and every named strategy declared
+under strategies: in the config, with their parameters.
+
+Examples:
+  # Show strategies
+  trading-cli strategies
+
+  # Open with one of them
+  trading-cli open --symbol ETH-USDT --side long --entry 3950 --sl 3900 --risk 1 --strategy scalp`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getExecutor().ExecuteListStrategies()
+	},
+}
@@ -40,6 +40,12 @@ Examples:
 			return fmt.Errorf("callback rate must be between 0 and 5%%")
 		}
 
+		resolved, err := exec.ResolveSymbol(cmd.Context(), trailSymbol)
+		if err != nil {
+			return err
+		}
+		trailSymbol = resolved
+
 		return exec.ExecuteTrailingStop(cmd.Context(), trailSymbol, trailTrigger, trailCallback)
 	},
 }
@@ -0,0 +1,259 @@
+// Package analytics computes trade performance figures shared by backtests
+// and trade history reports.
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Outcome is the result of one closed trade
+type Outcome struct {
+	PnL    float64   // Net of fees
+	R      float64   // PnL in multiples of the risk taken; zero when unknown
+	HasR   bool      // Whether R is known (history trades may lack a journaled stop)
+	Closed time.Time // When the trade closed, for daily returns
+}
+
+// Summary aggregates a set of outcomes
+type Summary struct {
+	Trades        int     `json:"trades"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	WinRate       float64 `json:"win_rate"` // Percent
+	NetPnL        float64 `json:"net_pnl"`
+	GrossWin      float64 `json:"gross_win"`
+	GrossLoss     float64 `json:"gross_loss"` // Negative
+	AvgWin        float64 `json:"avg_win"`
+	AvgLoss       float64 `json:"avg_loss"`      // Negative
+	ProfitFactor  float64 `json:"profit_factor"` // Gross win / gross loss; zero without losses
+	Expectancy    float64 `json:"expectancy"`    // Average PnL per trade
+	ExpectancyR   float64 `json:"expectancy_r"`  // Average R over trades with a known R
+	RTrades       int     `json:"r_trades"`      // Trades with a known R
+	MaxLossStreak int     `json:"max_loss_streak"`
+}
+
+// Summarize computes win rate, average win and loss, profit factor,
+// expectancy and the longest run of losses. Outcomes must be in close order.
+func Summarize(outcomes []Outcome) Summary {
+	s := Summary{Trades: len(outcomes)}
+	if s.Trades == 0 {
+		return s
+	}
+
+	var totalR float64
+	streak := 0
+	for _, o := range outcomes {
+		s.NetPnL += o.PnL
+		switch {
+		case o.PnL > 0:
+			s.Wins++
+			s.GrossWin += o.PnL
+			streak = 0
+		case o.PnL < 0:
+			s.Losses++
+			s.GrossLoss += o.PnL
+			streak++
+			s.MaxLossStreak = max(s.MaxLossStreak, streak)
+		}
+		if o.HasR {
+			s.RTrades++
+			totalR += o.R
+		}
+	}
+
+	s.WinRate = float64(s.Wins) / float64(s.Trades) * 100
+	s.Expectancy = s.NetPnL / float64(s.Trades)
+	if s.Wins > 0 {
+		s.AvgWin = s.GrossWin / float64(s.Wins)
+	}
+	if s.Losses > 0 {
+		s.AvgLoss = s.GrossLoss / float64(s.Losses)
+		s.ProfitFactor = s.GrossWin / -s.GrossLoss
+	}
+	if s.RTrades > 0 {
+		s.ExpectancyR = totalR / float64(s.RTrades)
+	}
+	return s
+}
+
+// Group is the performance of a slice of trades, such as one account's or
+// one symbol's. Sharpe and Sortino are zero when no equity is known.
+type Group struct {
+	Name string `json:"name"`
+	Summary
+	Sharpe  float64 `json:"sharpe,omitempty"`
+	Sortino float64 `json:"sortino,omitempty"`
+}
+
+// NewGroup summarizes outcomes, adding Sharpe and Sortino on daily returns
+// over the period when the equity at its end is known
+func NewGroup(name string, outcomes []Outcome, from, to time.Time, endEquity float64) Group {
+	group := Group{Name: name, Summary: Summarize(outcomes)}
+	if endEquity > 0 {
+		returns := DailyReturns(outcomes, from, to, endEquity)
+		group.Sharpe = Sharpe(returns)
+		group.Sortino = Sortino(returns)
+	}
+	return group
+}
+
+// Report is the performance of closed trades over a period, in total and
+// broken down by account and by symbol
+type Report struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Total    Group     `json:"total"`
+	Accounts []Group   `json:"accounts"`
+	Symbols  []Group   `json:"symbols"` // Best to worst by net PnL
+}
+
+// tradingDays annualizes daily ratios; crypto futures trade every day
+const tradingDays = 365
+
+// DailyReturns turns outcomes into one return per calendar day from from to
+// to, zero on days without closes. Each day's PnL is divided by the equity
+// at its start, walked back from endEquity (the balance at the end of to),
+// so deposits and withdrawals within the period skew the result.
+func DailyReturns(outcomes []Outcome, from, to time.Time, endEquity float64) []float64 {
+	start := startOfDay(from)
+	days := daysBetween(start, startOfDay(to)) + 1
+	if days < 1 || endEquity <= 0 {
+		return nil
+	}
+
+	pnl := make([]float64, days)
+	for _, o := range outcomes {
+		if day := daysBetween(start, startOfDay(o.Closed)); day >= 0 && day < days {
+			pnl[day] += o.PnL
+		}
+	}
+
+	returns := make([]float64, days)
+	equity := endEquity
+	for day := days - 1; day >= 0; day-- {
+		equity -= pnl[day]
+		if equity <= 0 {
+			return nil
+		}
+		returns[day] = pnl[day] / equity
+	}
+	return returns
+}
+
+// startOfDay truncates t to local midnight
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Local().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// daysBetween counts calendar days between two local midnights, which are
+// 23 or 25 hours apart across daylight saving changes
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+// Sharpe is the annualized mean daily return over its standard deviation,
+// or zero with fewer than two days or no variation
+func Sharpe(returns []float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+	mean := average(returns)
+
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	deviation := math.Sqrt(variance / float64(len(returns)-1))
+	if deviation == 0 {
+		return 0
+	}
+	return mean / deviation * math.Sqrt(tradingDays)
+}
+
+// Sortino is like Sharpe but only penalizes losing days, or zero when
+// there are none
+func Sortino(returns []float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+
+	var downside float64
+	for _, r := range returns {
+		if r < 0 {
+			downside += r * r
+		}
+	}
+	deviation := math.Sqrt(downside / float64(len(returns)))
+	if deviation == 0 {
+		return 0
+	}
+	return average(returns) / deviation * math.Sqrt(tradingDays)
+}
+
+func average(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// Drawdown is the largest peak-to-trough decline of an equity series
+type Drawdown struct {
+	Percent float64 // Positive, of the peak
+	Amount  float64
+	Peak    int // Index of the peak
+	Trough  int // Index of the trough
+}
+
+// MaxDrawdown finds the largest decline from a running peak
+func MaxDrawdown(equity []float64) Drawdown {
+	var dd Drawdown
+	peak := 0
+	for i, value := range equity {
+		if value > equity[peak] {
+			peak = i
+		}
+		if equity[peak] <= 0 {
+			continue
+		}
+		if pct := (equity[peak] - value) / equity[peak] * 100; pct > dd.Percent {
+			dd = Drawdown{Percent: pct, Amount: equity[peak] - value, Peak: peak, Trough: i}
+		}
+	}
+	return dd
+}
+
+// Bucket counts outcomes within an R range
+type Bucket struct {
+	Label string
+	Count int
+}
+
+// rEdges are the bucket boundaries for the R distribution
+var rEdges = []float64{-2, -1, 0, 1, 2, 3}
+
+// RDistribution buckets the known R multiples of the outcomes
+func RDistribution(outcomes []Outcome) []Bucket {
+	buckets := make([]Bucket, len(rEdges)+1)
+	buckets[0].Label = fmt.Sprintf("< %gR", rEdges[0])
+	for i := 1; i < len(rEdges); i++ {
+		buckets[i].Label = fmt.Sprintf("%gR to %gR", rEdges[i-1], rEdges[i])
+	}
+	buckets[len(rEdges)].Label = fmt.Sprintf(">= %gR", rEdges[len(rEdges)-1])
+
+	for _, o := range outcomes {
+		if !o.HasR || math.IsNaN(o.R) {
+			continue
+		}
+		i := 0
+		for i < len(rEdges) && o.R >= rEdges[i] {
+			i++
+		}
+		buckets[i].Count++
+	}
+	return buckets
+}
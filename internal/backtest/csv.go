@@ -0,0 +1,158 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+)
+
+// timeLayouts are the timestamp formats accepted besides unix seconds and
+// milliseconds
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseTime parses a unix timestamp (seconds or milliseconds) or a date
+func parseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if n > 1e12 {
+			return time.UnixMilli(n).UTC(), nil
+		}
+		return time.Unix(n, 0).UTC(), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+// csvTable reads a CSV file into rows, returning the header's column
+// indexes when the first row is a header (its first field isn't a time)
+func csvTable(path string) (map[string]int, [][]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rows [][]string
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("%s: no rows", path)
+	}
+
+	if _, err := parseTime(rows[0][0]); err == nil {
+		return nil, rows, nil
+	}
+
+	header := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		header[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	return header, rows[1:], nil
+}
+
+// column finds the index of the first matching header name, or the
+// fallback position when the file has no header
+func column(header map[string]int, fallback int, names ...string) int {
+	if header == nil {
+		return fallback
+	}
+	for _, name := range names {
+		if i, ok := header[name]; ok {
+			return i
+		}
+	}
+	return -1
+}
+
+// field returns a row's value at an index, or "" when missing
+func field(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+// LoadCandles reads OHLCV candles from a CSV file with columns time, open,
+// high, low, close and optionally volume, oldest first. A header row may
+// name the columns in any order.
+func LoadCandles(path string) ([]market.Kline, error) {
+	header, rows, err := csvTable(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cols := []int{
+		column(header, 0, "time", "timestamp", "date", "open_time", "datetime"),
+		column(header, 1, "open", "o"),
+		column(header, 2, "high", "h"),
+		column(header, 3, "low", "l"),
+		column(header, 4, "close", "c"),
+	}
+	for _, c := range cols {
+		if c < 0 {
+			return nil, fmt.Errorf("%s: header needs time, open, high, low and close columns", path)
+		}
+	}
+	volume := column(header, 5, "volume", "vol", "v")
+
+	candles := make([]market.Kline, 0, len(rows))
+	for n, row := range rows {
+		openTime, err := parseTime(field(row, cols[0]))
+		if err != nil {
+			return nil, fmt.Errorf("%s row %d: %w", path, n+1, err)
+		}
+
+		values := make([]float64, 4)
+		for i, c := range cols[1:] {
+			if values[i], err = strconv.ParseFloat(field(row, c), 64); err != nil {
+				return nil, fmt.Errorf("%s row %d: invalid price %q", path, n+1, field(row, c))
+			}
+		}
+
+		candle := market.Kline{OpenTime: openTime, Open: values[0], High: values[1], Low: values[2], Close: values[3]}
+		if v := field(row, volume); v != "" {
+			candle.Volume, _ = strconv.ParseFloat(v, 64)
+		}
+		if candle.High < candle.Low || candle.Open <= 0 || candle.Close <= 0 {
+			return nil, fmt.Errorf("%s row %d: inconsistent candle", path, n+1)
+		}
+		candles = append(candles, candle)
+	}
+
+	for i := 1; i < len(candles); i++ {
+		if !candles[i].OpenTime.After(candles[i-1].OpenTime) {
+			return nil, fmt.Errorf("%s: candles must be in ascending time order (row %d)", path, i+1)
+		}
+	}
+
+	return candles, nil
+}
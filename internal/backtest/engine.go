@@ -0,0 +1,257 @@
+// Package backtest replays entry signals over historical candles, sizing
+// and protecting each trade with the same strategy, calculator and fee code
+// as open, to show how a risk and RR setting would have performed.
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/agatticelli/calculator-go"
+	"github.com/agatticelli/strategy-go"
+	"github.com/agatticelli/trading-cli/internal/levels"
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-cli/internal/sizing"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// Config controls a backtest run
+type Config struct {
+	Symbol         string // For the strategy and output precision only
+	Strategy       strategy.Strategy
+	InitialBalance float64
+	RiskPercent    float64
+	Stop           levels.Expr  // Used when a signal has no stop (distance, percent or atr:)
+	Target         *levels.Expr // Overrides the strategy's take profit when set
+	ATRPeriod      int
+	Fees           sizing.Fees // Entries and exits fill at market (taker); stops add slippage
+	Compound       bool        // Size from current equity instead of the initial balance
+}
+
+// Trade is one simulated round trip
+type Trade struct {
+	Side      broker.Side
+	EntryTime time.Time
+	ExitTime  time.Time
+	Entry     float64
+	Exit      float64
+	Stop      float64
+	Target    float64
+	Size      float64
+	Fees      float64
+	PnL       float64 // Net of fees
+	R         float64 // PnL over the risk budget at entry
+	Reason    string  // stop, target or end
+}
+
+// EquityPoint is account equity at a candle close, open trades marked to
+// the close
+type EquityPoint struct {
+	Time   time.Time
+	Equity float64
+}
+
+// Result is the outcome of a backtest
+type Result struct {
+	Symbol   string
+	Initial  float64
+	Final    float64
+	Trades   []Trade
+	Equity   []EquityPoint
+	Skipped  int // Signals ignored: already in a trade, or levels unavailable
+	Warnings []string
+}
+
+// position is the open trade during a run
+type position struct {
+	trade    Trade
+	risk     float64
+	entryFee float64
+}
+
+// Run simulates the signals over the candles. One trade is open at a time;
+// signals arriving while in a trade are skipped. A candle that touches both
+// stop and target is assumed to hit the stop first.
+func Run(ctx context.Context, candles []market.Kline, signals []Signal, cfg Config) (*Result, error) {
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no candles")
+	}
+	if cfg.InitialBalance <= 0 {
+		return nil, fmt.Errorf("initial balance must be positive")
+	}
+
+	calc := calculator.New(125)
+	result := &Result{Symbol: cfg.Symbol, Initial: cfg.InitialBalance}
+	equity := cfg.InitialBalance
+
+	var open *position
+	next := 0
+	for i, candle := range candles {
+		// Signals due by this candle's open; the latest one wins
+		var signal *Signal
+		for next < len(signals) && !signals[next].Time.After(candle.OpenTime) {
+			if signal != nil {
+				result.Skipped++
+			}
+			signal = &signals[next]
+			next++
+		}
+		if signal != nil && open != nil {
+			result.Skipped++
+			signal = nil
+		}
+
+		if signal != nil {
+			capital := cfg.InitialBalance
+			if cfg.Compound {
+				capital = equity
+			}
+			entered, err := enter(ctx, calc, candles[:i], candle, *signal, capital, cfg)
+			if err != nil {
+				result.Skipped++
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %v", candle.OpenTime.Format(time.DateTime), err))
+			}
+			open = entered
+		}
+
+		if open != nil {
+			if exit, reason, ok := exitPrice(open.trade, candle, cfg.Fees.SlippageRate); ok {
+				equity += settle(calc, open, candle.OpenTime, exit, reason, cfg.Fees)
+				result.Trades = append(result.Trades, open.trade)
+				open = nil
+			}
+		}
+
+		marked := equity
+		if open != nil {
+			pnl, _ := calc.CalculateExpectedPnL(open.trade.Side, open.trade.Entry, candle.Close, open.trade.Size)
+			marked += pnl - open.entryFee
+		}
+		result.Equity = append(result.Equity, EquityPoint{Time: candle.OpenTime, Equity: marked})
+	}
+	result.Skipped += len(signals) - next
+
+	// Close whatever is still open at the last close
+	if open != nil {
+		last := candles[len(candles)-1]
+		equity += settle(calc, open, last.OpenTime, last.Close, "end", cfg.Fees)
+		result.Trades = append(result.Trades, open.trade)
+		result.Equity[len(result.Equity)-1].Equity = equity
+	}
+
+	result.Final = equity
+	return result, nil
+}
+
+// enter sizes a trade at the candle's open using the strategy
+func enter(ctx context.Context, calc *calculator.Calculator, history []market.Kline, candle market.Kline, signal Signal, capital float64, cfg Config) (*position, error) {
+	entry := candle.Open
+
+	var atr float64
+	if cfg.Stop.NeedsATR() || (cfg.Target != nil && cfg.Target.NeedsATR()) {
+		var err error
+		if atr, err = market.ATR(history, cfg.ATRPeriod); err != nil {
+			return nil, err
+		}
+	}
+
+	stop := signal.Stop
+	if stop <= 0 {
+		var err error
+		if stop, err = cfg.Stop.Stop(signal.Side, entry, atr); err != nil {
+			return nil, fmt.Errorf("stop %s: %w", cfg.Stop, err)
+		}
+	}
+	if err := calc.ValidateStopLoss(signal.Side, entry, stop); err != nil {
+		return nil, err
+	}
+	if (signal.Side == broker.SideLong && stop >= entry) || (signal.Side == broker.SideShort && stop <= entry) {
+		return nil, fmt.Errorf("stop %g is on the wrong side of entry %g", stop, entry)
+	}
+
+	plan, err := cfg.Strategy.CalculatePosition(ctx, strategy.PositionParams{
+		Symbol:         cfg.Symbol,
+		Side:           signal.Side,
+		EntryPrice:     entry,
+		StopLoss:       stop,
+		AccountBalance: capital,
+		RiskPercent:    cfg.RiskPercent,
+		MaxLeverage:    125,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sizing.ApplyFees(plan, cfg.Fees)
+	if plan.Size <= 0 {
+		return nil, fmt.Errorf("position size is zero")
+	}
+
+	target := signal.Target
+	if target <= 0 && cfg.Target != nil {
+		if target, err = cfg.Target.Target(signal.Side, entry, atr); err != nil {
+			return nil, fmt.Errorf("target %s: %w", cfg.Target, err)
+		}
+	}
+	if target <= 0 && len(plan.TakeProfits) > 0 {
+		target = plan.TakeProfits[0].Price
+	}
+	if target > 0 && ((signal.Side == broker.SideLong && target <= entry) || (signal.Side == broker.SideShort && target >= entry)) {
+		return nil, fmt.Errorf("target %g is on the wrong side of entry %g", target, entry)
+	}
+
+	// The risk budget includes the costs of being stopped out, as in open
+	risk := math.Abs(entry-stop)*plan.Size + sizing.Breakdown(plan, cfg.Fees).Total
+
+	return &position{
+		trade: Trade{
+			Side:      signal.Side,
+			EntryTime: candle.OpenTime,
+			Entry:     entry,
+			Stop:      stop,
+			Target:    target,
+			Size:      plan.Size,
+		},
+		risk:     risk,
+		entryFee: plan.Size * entry * cfg.Fees.EntryRate,
+	}, nil
+}
+
+// exitPrice reports whether a candle closes the trade and at what price.
+// Gaps through a level fill at the open; stops also pay slippage.
+func exitPrice(trade Trade, candle market.Kline, slippage float64) (float64, string, bool) {
+	if trade.Side == broker.SideLong {
+		switch {
+		case candle.Low <= trade.Stop:
+			return min(trade.Stop, candle.Open) * (1 - slippage), "stop", true
+		case trade.Target > 0 && candle.High >= trade.Target:
+			return max(trade.Target, candle.Open), "target", true
+		}
+		return 0, "", false
+	}
+
+	switch {
+	case candle.High >= trade.Stop:
+		return max(trade.Stop, candle.Open) * (1 + slippage), "stop", true
+	case trade.Target > 0 && candle.Low <= trade.Target:
+		return min(trade.Target, candle.Open), "target", true
+	}
+	return 0, "", false
+}
+
+// settle closes the trade and returns its net PnL
+func settle(calc *calculator.Calculator, open *position, at time.Time, exit float64, reason string, fees sizing.Fees) float64 {
+	trade := &open.trade
+	gross, _ := calc.CalculateExpectedPnL(trade.Side, trade.Entry, exit, trade.Size)
+
+	trade.ExitTime = at
+	trade.Exit = exit
+	trade.Reason = reason
+	trade.Fees = open.entryFee + trade.Size*exit*fees.ExitRate
+	trade.PnL = gross - trade.Fees
+	if open.risk > 0 {
+		trade.R = trade.PnL / open.risk
+	}
+	return trade.PnL
+}
@@ -0,0 +1,166 @@
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// Signal is a request to enter at the open of the first candle at or after
+// Time. Stop and Target override the configured levels when set.
+type Signal struct {
+	Time   time.Time
+	Side   broker.Side
+	Stop   float64
+	Target float64
+}
+
+// LoadSignals reads signals from a CSV file with columns time, side and
+// optionally stop and target prices
+func LoadSignals(path string) ([]Signal, error) {
+	header, rows, err := csvTable(path)
+	if err != nil {
+		return nil, err
+	}
+
+	timeCol := column(header, 0, "time", "timestamp", "date", "datetime")
+	sideCol := column(header, 1, "side", "direction", "signal")
+	stopCol := column(header, 2, "stop", "sl", "stop_loss")
+	targetCol := column(header, 3, "target", "tp", "take_profit")
+	if timeCol < 0 || sideCol < 0 {
+		return nil, fmt.Errorf("%s: header needs time and side columns", path)
+	}
+
+	signals := make([]Signal, 0, len(rows))
+	for n, row := range rows {
+		at, err := parseTime(field(row, timeCol))
+		if err != nil {
+			return nil, fmt.Errorf("%s row %d: %w", path, n+1, err)
+		}
+		side, err := parseSide(field(row, sideCol))
+		if err != nil {
+			return nil, fmt.Errorf("%s row %d: %w", path, n+1, err)
+		}
+
+		signal := Signal{Time: at, Side: side}
+		if v := field(row, stopCol); v != "" {
+			if signal.Stop, err = strconv.ParseFloat(v, 64); err != nil {
+				return nil, fmt.Errorf("%s row %d: invalid stop %q", path, n+1, v)
+			}
+		}
+		if v := field(row, targetCol); v != "" {
+			if signal.Target, err = strconv.ParseFloat(v, 64); err != nil {
+				return nil, fmt.Errorf("%s row %d: invalid target %q", path, n+1, v)
+			}
+		}
+		signals = append(signals, signal)
+	}
+
+	sort.SliceStable(signals, func(i, j int) bool { return signals[i].Time.Before(signals[j].Time) })
+	return signals, nil
+}
+
+// parseSide accepts long/short in English or Spanish and buy/sell
+func parseSide(s string) (broker.Side, error) {
+	switch strings.ToLower(s) {
+	case "long", "buy", "largo":
+		return broker.SideLong, nil
+	case "short", "sell", "corto":
+		return broker.SideShort, nil
+	default:
+		return "", fmt.Errorf("invalid side %q (use long or short)", s)
+	}
+}
+
+// RuleSignals generates signals from a built-in rule evaluated on candle
+// closes, entering at the next candle's open:
+//
+//	sma:<fast>:<slow>  long when the fast SMA crosses above the slow one, short below
+//	breakout:<n>       long on a close above the prior n-candle high, short below the low
+func RuleSignals(rule string, candles []market.Kline) ([]Signal, error) {
+	name, args, _ := strings.Cut(strings.ToLower(strings.TrimSpace(rule)), ":")
+
+	params, err := ruleParams(args)
+	if err != nil {
+		return nil, fmt.Errorf("rule %s: %w", rule, err)
+	}
+
+	switch {
+	case name == "sma" && len(params) == 2 && params[0] < params[1]:
+		return smaCross(candles, params[0], params[1]), nil
+	case name == "sma":
+		return nil, fmt.Errorf("rule %s: use sma:<fast>:<slow> with fast < slow", rule)
+	case name == "breakout" && len(params) == 1:
+		return breakout(candles, params[0]), nil
+	case name == "breakout":
+		return nil, fmt.Errorf("rule %s: use breakout:<candles>", rule)
+	default:
+		return nil, fmt.Errorf("unknown rule %q (available: sma:<fast>:<slow>, breakout:<n>)", rule)
+	}
+}
+
+// ruleParams parses colon-separated positive integers
+func ruleParams(args string) ([]int, error) {
+	if args == "" {
+		return nil, nil
+	}
+	parts := strings.Split(args, ":")
+	params := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid parameter %q", part)
+		}
+		params[i] = n
+	}
+	return params, nil
+}
+
+// smaCross signals when the fast SMA of closes crosses the slow one
+func smaCross(candles []market.Kline, fast, slow int) []Signal {
+	var signals []Signal
+	sma := func(end, period int) float64 {
+		sum := 0.0
+		for _, k := range candles[end-period+1 : end+1] {
+			sum += k.Close
+		}
+		return sum / float64(period)
+	}
+
+	for i := slow; i < len(candles)-1; i++ {
+		prev := sma(i-1, fast) - sma(i-1, slow)
+		curr := sma(i, fast) - sma(i, slow)
+		switch {
+		case prev <= 0 && curr > 0:
+			signals = append(signals, Signal{Time: candles[i+1].OpenTime, Side: broker.SideLong})
+		case prev >= 0 && curr < 0:
+			signals = append(signals, Signal{Time: candles[i+1].OpenTime, Side: broker.SideShort})
+		}
+	}
+	return signals
+}
+
+// breakout signals when a close leaves the range of the prior n candles
+func breakout(candles []market.Kline, n int) []Signal {
+	var signals []Signal
+	for i := n; i < len(candles)-1; i++ {
+		high, low := candles[i-n].High, candles[i-n].Low
+		for _, k := range candles[i-n+1 : i] {
+			high = max(high, k.High)
+			low = min(low, k.Low)
+		}
+
+		switch {
+		case candles[i].Close > high:
+			signals = append(signals, Signal{Time: candles[i+1].OpenTime, Side: broker.SideLong})
+		case candles[i].Close < low:
+			signals = append(signals, Signal{Time: candles[i+1].OpenTime, Side: broker.SideShort})
+		}
+	}
+	return signals
+}
@@ -0,0 +1,42 @@
+package brokers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/agatticelli/trading-cli/internal/brokers/binance"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+func init() {
+	Register(Registration{
+		Name:        "binance",
+		Description: "Binance USDⓈ-M perpetual futures (one-way position mode)",
+		Fields: []Field{
+			{Name: "base_url", Description: "override the REST endpoint"},
+			{Name: "recv_window", Description: "signed request validity window in ms"},
+		},
+		Capabilities: Capabilities{
+			Demo:          true,
+			BracketOrders: true,
+			TrailingStop:  true,
+		},
+		New: func(settings Settings) (broker.Broker, error) {
+			opts := []binance.Option{}
+
+			if baseURL := settings.Option("base_url", ""); baseURL != "" {
+				opts = append(opts, binance.WithBaseURL(baseURL))
+			}
+
+			if raw := settings.Option("recv_window", ""); raw != "" {
+				ms, err := strconv.ParseInt(raw, 10, 64)
+				if err != nil || ms <= 0 {
+					return nil, fmt.Errorf("invalid recv_window: %s", raw)
+				}
+				opts = append(opts, binance.WithRecvWindow(ms))
+			}
+
+			return binance.NewClient(settings.APIKey, settings.SecretKey, settings.Demo, opts...), nil
+		},
+	})
+}
@@ -0,0 +1,197 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+const settlementAsset = "USDT"
+
+type balanceResponse struct {
+	Asset            string `json:"asset"`
+	Balance          string `json:"balance"`
+	CrossUnPnl       string `json:"crossUnPnl"`
+	AvailableBalance string `json:"availableBalance"`
+}
+
+type positionResponse struct {
+	Symbol           string `json:"symbol"`
+	PositionAmt      string `json:"positionAmt"`
+	EntryPrice       string `json:"entryPrice"`
+	MarkPrice        string `json:"markPrice"`
+	UnRealizedProfit string `json:"unRealizedProfit"`
+	LiquidationPrice string `json:"liquidationPrice"`
+	Leverage         string `json:"leverage"`
+	MarginType       string `json:"marginType"`
+}
+
+type tickerResponse struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+}
+
+// GetBalance returns the USDT futures wallet balance
+func (c *Client) GetBalance(ctx context.Context) (*broker.Balance, error) {
+	var balances []balanceResponse
+	if err := c.signed(ctx, http.MethodGet, "/fapi/v2/balance", nil, &balances); err != nil {
+		return nil, err
+	}
+
+	for _, b := range balances {
+		if b.Asset != settlementAsset {
+			continue
+		}
+
+		total := parseFloat(b.Balance)
+		available := parseFloat(b.AvailableBalance)
+		unrealized := parseFloat(b.CrossUnPnl)
+
+		inUse := total + unrealized - available
+		if inUse < 0 {
+			inUse = 0
+		}
+
+		return &broker.Balance{
+			Asset:         b.Asset,
+			Total:         total,
+			Available:     available,
+			InUse:         inUse,
+			UnrealizedPnL: unrealized,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no %s balance found", settlementAsset)
+}
+
+// GetPositions returns all non-empty positions, optionally filtered by symbol
+func (c *Client) GetPositions(ctx context.Context, filter *broker.PositionFilter) ([]*broker.Position, error) {
+	symbol := ""
+	if filter != nil {
+		symbol = filter.Symbol
+	}
+
+	raw, err := c.positionRisk(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]*broker.Position, 0)
+	for _, p := range raw {
+		amount := parseFloat(p.PositionAmt)
+		if amount == 0 {
+			continue
+		}
+		positions = append(positions, toPosition(p, amount))
+	}
+
+	return positions, nil
+}
+
+// GetPositionRisks returns the liquidation price and margin mode Binance
+// reports for each non-empty position, optionally for one symbol
+func (c *Client) GetPositionRisks(ctx context.Context, symbol string) ([]market.PositionRisk, error) {
+	raw, err := c.positionRisk(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	risks := make([]market.PositionRisk, 0)
+	for _, p := range raw {
+		amount := parseFloat(p.PositionAmt)
+		if amount == 0 {
+			continue
+		}
+		side := broker.SideLong
+		if amount < 0 {
+			side = broker.SideShort
+		}
+		mode, _ := market.ParseMarginMode(p.MarginType)
+		risks = append(risks, market.PositionRisk{
+			Symbol:      fromExchangeSymbol(p.Symbol),
+			Side:        side,
+			Liquidation: parseFloat(p.LiquidationPrice),
+			MarginMode:  mode,
+		})
+	}
+	return risks, nil
+}
+
+// positionRisk lists every symbol's position entry, or one symbol's
+func (c *Client) positionRisk(ctx context.Context, symbol string) ([]positionResponse, error) {
+	params := url.Values{}
+	if symbol != "" {
+		params.Set("symbol", toExchangeSymbol(symbol))
+	}
+
+	var raw []positionResponse
+	if err := c.signed(ctx, http.MethodGet, "/fapi/v2/positionRisk", params, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// GetPosition returns the open position for a symbol, or nil if flat
+func (c *Client) GetPosition(ctx context.Context, symbol string) (*broker.Position, error) {
+	positions, err := c.GetPositions(ctx, &broker.PositionFilter{Symbol: symbol})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(positions) == 0 {
+		return nil, nil
+	}
+	return positions[0], nil
+}
+
+// SetLeverage sets the symbol leverage. Binance leverage is per symbol, so
+// the side is ignored.
+func (c *Client) SetLeverage(ctx context.Context, symbol, side string, leverage int) error {
+	params := url.Values{}
+	params.Set("symbol", toExchangeSymbol(symbol))
+	params.Set("leverage", strconv.Itoa(leverage))
+
+	return c.signed(ctx, http.MethodPost, "/fapi/v1/leverage", params, nil)
+}
+
+// GetCurrentPrice returns the latest traded price for a symbol
+func (c *Client) GetCurrentPrice(ctx context.Context, symbol string) (float64, error) {
+	params := url.Values{}
+	params.Set("symbol", toExchangeSymbol(symbol))
+
+	var ticker tickerResponse
+	if err := c.public(ctx, http.MethodGet, "/fapi/v1/ticker/price", params, &ticker); err != nil {
+		return 0, err
+	}
+
+	price := parseFloat(ticker.Price)
+	if price <= 0 {
+		return 0, fmt.Errorf("invalid price for %s: %q", symbol, ticker.Price)
+	}
+	return price, nil
+}
+
+func toPosition(p positionResponse, amount float64) *broker.Position {
+	side := broker.SideLong
+	if amount < 0 {
+		side = broker.SideShort
+		amount = -amount
+	}
+
+	leverage, _ := strconv.Atoi(p.Leverage)
+
+	return &broker.Position{
+		Symbol:        fromExchangeSymbol(p.Symbol),
+		Side:          side,
+		Size:          amount,
+		EntryPrice:    parseFloat(p.EntryPrice),
+		MarkPrice:     parseFloat(p.MarkPrice),
+		UnrealizedPnL: parseFloat(p.UnRealizedProfit),
+		Leverage:      leverage,
+	}
+}
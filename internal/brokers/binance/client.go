@@ -0,0 +1,195 @@
+// Package binance implements broker.Broker for Binance USDⓈ-M perpetual futures.
+//
+// The client expects the account to run in one-way position mode, which is
+// the Binance default.
+package binance
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ProductionURL is the USDⓈ-M futures REST endpoint
+	ProductionURL = "https://fapi.binance.com"
+	// TestnetURL is the USDⓈ-M futures testnet REST endpoint
+	TestnetURL = "https://testnet.binancefuture.com"
+
+	defaultRecvWindow = 5000
+)
+
+// quoteAssets are the settlement assets used to split exchange symbols
+// back into the CLI's BASE-QUOTE format
+var quoteAssets = []string{"USDT", "USDC", "FDUSD", "BUSD"}
+
+// Client is a Binance USDⓈ-M futures REST client
+type Client struct {
+	apiKey     string
+	secretKey  string
+	baseURL    string
+	recvWindow int64
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// Option customizes a Client
+type Option func(*Client)
+
+// WithBaseURL overrides the REST endpoint (useful for proxies and test stubs)
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithRecvWindow sets the signed request validity window in milliseconds
+func WithRecvWindow(ms int64) Option {
+	return func(c *Client) {
+		c.recvWindow = ms
+	}
+}
+
+// WithHTTPClient replaces the underlying HTTP client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient creates a new Binance futures client
+func NewClient(apiKey, secretKey string, demo bool, opts ...Option) *Client {
+	baseURL := ProductionURL
+	if demo {
+		baseURL = TestnetURL
+	}
+
+	c := &Client{
+		apiKey:     apiKey,
+		secretKey:  secretKey,
+		baseURL:    baseURL,
+		recvWindow: defaultRecvWindow,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		now:        time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// APIError is an error payload returned by Binance
+type APIError struct {
+	Status  int
+	Code    int    `json:"code"`
+	Message string `json:"msg"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("binance: %s (code %d, http %d)", e.Message, e.Code, e.Status)
+}
+
+// public performs an unsigned request
+func (c *Client) public(ctx context.Context, method, path string, params url.Values, out interface{}) error {
+	return c.do(ctx, method, path, params, false, out)
+}
+
+// signed performs a request authenticated with the account keys
+func (c *Client) signed(ctx context.Context, method, path string, params url.Values, out interface{}) error {
+	return c.do(ctx, method, path, params, true, out)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, params url.Values, sign bool, out interface{}) error {
+	if params == nil {
+		params = url.Values{}
+	}
+
+	query := params.Encode()
+	if sign {
+		params.Set("timestamp", strconv.FormatInt(c.now().UnixMilli(), 10))
+		params.Set("recvWindow", strconv.FormatInt(c.recvWindow, 10))
+		query = params.Encode()
+
+		mac := hmac.New(sha256.New, []byte(c.secretKey))
+		mac.Write([]byte(query))
+		query += "&signature=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	endpoint := c.baseURL + path
+	if query != "" {
+		endpoint += "?" + query
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	if sign {
+		req.Header.Set("X-MBX-APIKEY", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		apiErr := &APIError{Status: resp.StatusCode}
+		if json.Unmarshal(body, apiErr) != nil || apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(body))
+		}
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// toExchangeSymbol converts ETH-USDT into ETHUSDT
+func toExchangeSymbol(symbol string) string {
+	return strings.ToUpper(strings.ReplaceAll(symbol, "-", ""))
+}
+
+// fromExchangeSymbol converts ETHUSDT into ETH-USDT
+func fromExchangeSymbol(symbol string) string {
+	for _, quote := range quoteAssets {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return symbol[:len(symbol)-len(quote)] + "-" + quote
+		}
+	}
+	return symbol
+}
+
+// parseFloat parses Binance's string-encoded numbers, treating blanks as zero
+func parseFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// formatFloat renders a number without exponent or trailing zeros
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,449 @@
+package binance
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+const (
+	testAPIKey    = "test-key"
+	testSecretKey = "test-secret"
+)
+
+// reply is a recorded exchange response
+type reply struct {
+	status  int
+	fixture string
+}
+
+// stub replays recorded responses per "METHOD /path", in order, and keeps
+// the requests it received
+type stub struct {
+	t        *testing.T
+	mu       sync.Mutex
+	replies  map[string][]reply
+	requests []recordedRequest
+}
+
+type recordedRequest struct {
+	route  string
+	params url.Values
+}
+
+func newStub(t *testing.T) (*stub, *Client) {
+	t.Helper()
+
+	s := &stub{t: t, replies: make(map[string][]reply)}
+	server := httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(server.Close)
+
+	now := time.UnixMilli(1717171717000)
+	client := NewClient(testAPIKey, testSecretKey, false, WithBaseURL(server.URL))
+	client.now = func() time.Time { return now }
+
+	return s, client
+}
+
+// on queues responses for a route; the last one repeats
+func (s *stub) on(route string, replies ...reply) {
+	s.replies[route] = append(s.replies[route], replies...)
+}
+
+func (s *stub) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	route := r.Method + " " + r.URL.Path
+	params := r.URL.Query()
+	s.requests = append(s.requests, recordedRequest{route: route, params: params})
+
+	if signature := params.Get("signature"); signature != "" {
+		if r.Header.Get("X-MBX-APIKEY") != testAPIKey {
+			s.t.Errorf("%s: missing API key header", route)
+		}
+		query := strings.TrimSuffix(r.URL.RawQuery, "&signature="+signature)
+		mac := hmac.New(sha256.New, []byte(testSecretKey))
+		mac.Write([]byte(query))
+		if want := hex.EncodeToString(mac.Sum(nil)); signature != want {
+			s.t.Errorf("%s: bad signature", route)
+		}
+	}
+
+	queue := s.replies[route]
+	if len(queue) == 0 {
+		s.t.Errorf("unexpected request: %s", route)
+		http.Error(w, `{"code":-1,"msg":"no recorded response"}`, http.StatusNotFound)
+		return
+	}
+	next := queue[0]
+	if len(queue) > 1 {
+		s.replies[route] = queue[1:]
+	}
+
+	body, err := os.ReadFile(filepath.Join("testdata", next.fixture))
+	if err != nil {
+		s.t.Fatalf("fixture %s: %v", next.fixture, err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(next.status)
+	w.Write(body)
+}
+
+// sent returns the parameters of every request made to a route
+func (s *stub) sent(route string) []url.Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var params []url.Values
+	for _, req := range s.requests {
+		if req.route == route {
+			params = append(params, req.params)
+		}
+	}
+	return params
+}
+
+func ok(fixture string) reply       { return reply{status: http.StatusOK, fixture: fixture} }
+func rejected(fixture string) reply { return reply{status: http.StatusBadRequest, fixture: fixture} }
+
+func expectParams(t *testing.T, got url.Values, want map[string]string) {
+	t.Helper()
+	for key, value := range want {
+		if got.Get(key) != value {
+			t.Errorf("%s = %q, want %q", key, got.Get(key), value)
+		}
+	}
+}
+
+func TestGetBalance(t *testing.T) {
+	s, client := newStub(t)
+	s.on("GET /fapi/v2/balance", ok("balance.json"))
+
+	balance, err := client.GetBalance(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if balance.Asset != "USDT" || balance.Total != 10250.5 || balance.Available != 8100.25 || balance.UnrealizedPnL != -42.25 {
+		t.Errorf("unexpected balance: %+v", balance)
+	}
+	if want := 10250.5 - 42.25 - 8100.25; balance.InUse != want {
+		t.Errorf("in use = %v, want %v", balance.InUse, want)
+	}
+}
+
+func TestGetPositions(t *testing.T) {
+	s, client := newStub(t)
+	s.on("GET /fapi/v2/positionRisk", ok("position_risk.json"))
+
+	positions, err := client.GetPositions(context.Background(), &broker.PositionFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(positions) != 2 {
+		t.Fatalf("got %d positions, want 2 (flat symbols skipped)", len(positions))
+	}
+	eth, btc := positions[0], positions[1]
+	if eth.Symbol != "ETH-USDT" || eth.Side != broker.SideLong || eth.Size != 0.5 || eth.EntryPrice != 3950.25 || eth.Leverage != 10 {
+		t.Errorf("unexpected long: %+v", eth)
+	}
+	if btc.Symbol != "BTC-USDT" || btc.Side != broker.SideShort || btc.Size != 0.01 || btc.Leverage != 20 {
+		t.Errorf("unexpected short: %+v", btc)
+	}
+}
+
+func TestGetPositionRisks(t *testing.T) {
+	s, client := newStub(t)
+	s.on("GET /fapi/v2/positionRisk", ok("position_risk.json"))
+
+	risks, err := client.GetPositionRisks(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(risks) != 2 {
+		t.Fatalf("got %d position risks, want 2 (flat symbols skipped)", len(risks))
+	}
+	eth, btc := risks[0], risks[1]
+	if eth.Symbol != "ETH-USDT" || eth.Side != broker.SideLong || eth.Liquidation != 3412.55 || eth.MarginMode != market.MarginIsolated {
+		t.Errorf("unexpected isolated long: %+v", eth)
+	}
+	if btc.Symbol != "BTC-USDT" || btc.Side != broker.SideShort || btc.Liquidation != 0 || btc.MarginMode != market.MarginCross {
+		t.Errorf("unexpected cross short: %+v", btc)
+	}
+}
+
+func TestGetOrders(t *testing.T) {
+	s, client := newStub(t)
+	s.on("GET /fapi/v1/openOrders", ok("open_orders.json"))
+
+	orders, err := client.GetOrders(context.Background(), &broker.OrderFilter{Symbol: "ETH-USDT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectParams(t, s.sent("GET /fapi/v1/openOrders")[0], map[string]string{"symbol": "ETHUSDT"})
+
+	if len(orders) != 3 {
+		t.Fatalf("got %d orders, want 3", len(orders))
+	}
+	stop, tp, trailing := orders[0], orders[1], orders[2]
+	if stop.ID != "8389765519" || stop.Type != broker.OrderTypeStop || stop.Side != broker.SideShort || stop.StopPrice != 3900 || !stop.ReduceOnly {
+		t.Errorf("unexpected stop: %+v", stop)
+	}
+	if tp.Type != broker.OrderTypeTakeProfit || tp.Price != 4100 || tp.Status != broker.OrderStatusNew {
+		t.Errorf("unexpected take profit: %+v", tp)
+	}
+	if trailing.Type != broker.OrderTypeTrailingStop || trailing.StopPrice != 66500 || trailing.Symbol != "BTC-USDT" {
+		t.Errorf("unexpected trailing stop: %+v", trailing)
+	}
+}
+
+func TestSetLeverage(t *testing.T) {
+	s, client := newStub(t)
+	s.on("POST /fapi/v1/leverage", ok("leverage.json"))
+
+	if err := client.SetLeverage(context.Background(), "ETH-USDT", "LONG", 10); err != nil {
+		t.Fatal(err)
+	}
+	expectParams(t, s.sent("POST /fapi/v1/leverage")[0], map[string]string{"symbol": "ETHUSDT", "leverage": "10"})
+}
+
+func bracketRequest() *broker.OrderRequest {
+	return &broker.OrderRequest{
+		Symbol:     "ETH-USDT",
+		Side:       broker.SideLong,
+		Type:       broker.OrderTypeMarket,
+		Size:       0.5,
+		StopLoss:   &broker.StopLossConfig{TriggerPrice: 3850, WorkingType: broker.WorkingTypeMark},
+		TakeProfit: &broker.TakeProfitConfig{TriggerPrice: 4150, WorkingType: broker.WorkingTypeMark},
+	}
+}
+
+func TestPlaceOrderBracket(t *testing.T) {
+	s, client := newStub(t)
+	s.on("POST /fapi/v1/order", ok("order_market_filled.json"), ok("order_stop_new.json"), ok("order_take_profit_new.json"))
+
+	order, err := client.PlaceOrder(context.Background(), bracketRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.ID != "8389765600" || order.Status != broker.OrderStatusFilled {
+		t.Errorf("unexpected entry: %+v", order)
+	}
+
+	sent := s.sent("POST /fapi/v1/order")
+	if len(sent) != 3 {
+		t.Fatalf("sent %d orders, want entry, stop and take profit", len(sent))
+	}
+	expectParams(t, sent[0], map[string]string{"symbol": "ETHUSDT", "side": "BUY", "type": "MARKET", "quantity": "0.5"})
+	expectParams(t, sent[1], map[string]string{"side": "SELL", "type": "STOP_MARKET", "stopPrice": "3850", "reduceOnly": "true", "workingType": "MARK_PRICE"})
+	expectParams(t, sent[2], map[string]string{"side": "SELL", "type": "TAKE_PROFIT_MARKET", "stopPrice": "4150", "reduceOnly": "true"})
+
+	// Legs carry the entry's client order ID so they can be found later
+	entryID := sent[0].Get("newClientOrderId")
+	if !strings.HasPrefix(entryID, bracketPrefix) {
+		t.Fatalf("entry client order ID %q is not tagged as a bracket", entryID)
+	}
+	expectParams(t, sent[1], map[string]string{"newClientOrderId": entryID + "-sl"})
+	expectParams(t, sent[2], map[string]string{"newClientOrderId": entryID + "-tp"})
+}
+
+func TestCancelOrderCancelsBracketLegs(t *testing.T) {
+	s, client := newStub(t)
+	s.on("DELETE /fapi/v1/order",
+		ok("order_bracket_entry_canceled.json"),
+		ok("order_stop_canceled.json"),
+		rejected("error_unknown_order.json")) // Take profit already gone
+
+	if err := client.CancelOrder(context.Background(), "ETH-USDT", "8389765610"); err != nil {
+		t.Fatal(err)
+	}
+
+	canceled := s.sent("DELETE /fapi/v1/order")
+	if len(canceled) != 3 {
+		t.Fatalf("canceled %d orders, want the entry and both legs", len(canceled))
+	}
+	expectParams(t, canceled[0], map[string]string{"orderId": "8389765610"})
+	expectParams(t, canceled[1], map[string]string{"origClientOrderId": "tc-lwz8k1ch-sl"})
+	expectParams(t, canceled[2], map[string]string{"origClientOrderId": "tc-lwz8k1ch-tp"})
+}
+
+func TestCancelOrderKeepsLegsOfUntaggedOrders(t *testing.T) {
+	s, client := newStub(t)
+	s.on("DELETE /fapi/v1/order", ok("order_limit_canceled.json"))
+
+	if err := client.CancelOrder(context.Background(), "ETH-USDT", "8389765601"); err != nil {
+		t.Fatal(err)
+	}
+	if canceled := s.sent("DELETE /fapi/v1/order"); len(canceled) != 1 {
+		t.Errorf("canceled %d orders, want only the requested one", len(canceled))
+	}
+}
+
+func TestGetStaleLegs(t *testing.T) {
+	s, client := newStub(t)
+	s.on("GET /fapi/v1/openOrders", ok("open_orders_stale_legs.json"))
+	s.on("GET /fapi/v1/order", ok("order_bracket_entry_canceled.json"), ok("order_bracket_entry_filled.json"))
+
+	stale, err := client.GetStaleLegs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ETH's entry was canceled unfilled; BTC's filled and SOL's stop is
+	// no bracket leg
+	if len(stale) != 2 || stale[0].ID != "8389765611" || stale[1].ID != "8389765612" {
+		t.Fatalf("got %d stale legs, want ETH's stop and take profit: %+v", len(stale), stale)
+	}
+	queried := s.sent("GET /fapi/v1/order")
+	if len(queried) != 2 {
+		t.Fatalf("queried %d entries, want one per bracket", len(queried))
+	}
+	expectParams(t, queried[0], map[string]string{"symbol": "ETHUSDT", "origClientOrderId": "tc-lwz8k1ch"})
+	expectParams(t, queried[1], map[string]string{"symbol": "BTCUSDT", "origClientOrderId": "tc-lwz9aa01"})
+}
+
+func TestPlaceOrderBracketClosesFilledEntry(t *testing.T) {
+	s, client := newStub(t)
+	s.on("POST /fapi/v1/order",
+		ok("order_market_filled.json"),
+		ok("order_stop_new.json"),
+		rejected("error_would_trigger.json"),
+		ok("order_close_filled.json"))
+	s.on("DELETE /fapi/v1/order", ok("order_stop_canceled.json"))
+
+	order, err := client.PlaceOrder(context.Background(), bracketRequest())
+	if err == nil {
+		t.Fatal("expected the rejected take profit to fail the order")
+	}
+	if errors.Is(err, ErrUnprotectedPosition) {
+		t.Errorf("position was closed, got %v", err)
+	}
+	if order != nil {
+		t.Errorf("rolled back entry returned: %+v", order)
+	}
+
+	// The placed stop is canceled, then the filled entry is closed
+	canceled := s.sent("DELETE /fapi/v1/order")
+	if len(canceled) != 1 {
+		t.Fatalf("canceled %d orders, want the stop leg only", len(canceled))
+	}
+	expectParams(t, canceled[0], map[string]string{"orderId": "8389765602"})
+
+	sent := s.sent("POST /fapi/v1/order")
+	if len(sent) != 4 {
+		t.Fatalf("sent %d orders, want entry, stop, take profit and close", len(sent))
+	}
+	expectParams(t, sent[3], map[string]string{"side": "SELL", "type": "MARKET", "quantity": "0.5", "reduceOnly": "true"})
+}
+
+func TestPlaceOrderBracketCancelsRestingEntry(t *testing.T) {
+	s, client := newStub(t)
+	req := bracketRequest()
+	req.Type, req.Price, req.TakeProfit = broker.OrderTypeLimit, 3900, nil
+
+	s.on("POST /fapi/v1/order",
+		ok("order_limit_new.json"),
+		rejected("error_would_trigger.json"),
+		ok("order_close_filled.json"))
+	s.on("DELETE /fapi/v1/order", ok("order_limit_canceled.json"))
+
+	if _, err := client.PlaceOrder(context.Background(), req); err == nil {
+		t.Fatal("expected the rejected stop to fail the order")
+	}
+
+	canceled := s.sent("DELETE /fapi/v1/order")
+	if len(canceled) != 1 {
+		t.Fatalf("canceled %d orders, want the entry", len(canceled))
+	}
+	expectParams(t, canceled[0], map[string]string{"orderId": "8389765601"})
+
+	// 0.2 filled before the cancel and is closed
+	sent := s.sent("POST /fapi/v1/order")
+	if len(sent) != 3 {
+		t.Fatalf("sent %d orders, want entry, stop and close", len(sent))
+	}
+	expectParams(t, sent[2], map[string]string{"side": "SELL", "type": "MARKET", "quantity": "0.2", "reduceOnly": "true"})
+}
+
+func TestPlaceOrderBracketReportsUnprotectedPosition(t *testing.T) {
+	s, client := newStub(t)
+	s.on("POST /fapi/v1/order",
+		ok("order_market_filled.json"),
+		rejected("error_would_trigger.json"),
+		rejected("error_reduce_only.json"))
+
+	order, err := client.PlaceOrder(context.Background(), bracketRequest())
+	if !errors.Is(err, ErrUnprotectedPosition) {
+		t.Fatalf("got %v, want ErrUnprotectedPosition", err)
+	}
+	if order == nil || order.ID != "8389765600" {
+		t.Errorf("the open entry should be returned, got %+v", order)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != -2021 {
+		t.Errorf("the rejected leg's error should be wrapped, got %v", err)
+	}
+}
+
+func TestPlaceTrailingStop(t *testing.T) {
+	s, client := newStub(t)
+	s.on("POST /fapi/v1/order", ok("order_trailing_new.json"))
+
+	order, err := client.PlaceOrder(context.Background(), &broker.OrderRequest{
+		Symbol:     "ETH-USDT",
+		Side:       broker.SideShort,
+		Type:       broker.OrderTypeTrailingStop,
+		Size:       0.5,
+		ReduceOnly: true,
+		Trailing:   &broker.TrailingConfig{ActivationPrice: 4000, CallbackRate: 0.005},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Type != broker.OrderTypeTrailingStop || order.StopPrice != 4000 {
+		t.Errorf("unexpected trailing stop: %+v", order)
+	}
+
+	expectParams(t, s.sent("POST /fapi/v1/order")[0], map[string]string{
+		"type":            "TRAILING_STOP_MARKET",
+		"side":            "SELL",
+		"activationPrice": "4000",
+		"callbackRate":    "0.5",
+		"reduceOnly":      "true",
+	})
+}
+
+func TestPlaceTrailingStopRejectsInvalidCallback(t *testing.T) {
+	_, client := newStub(t)
+
+	for _, rate := range []float64{0.0005, 0.0125, 0.06, 0} {
+		_, err := client.PlaceOrder(context.Background(), &broker.OrderRequest{
+			Symbol:   "ETH-USDT",
+			Side:     broker.SideShort,
+			Type:     broker.OrderTypeTrailingStop,
+			Size:     0.5,
+			Trailing: &broker.TrailingConfig{CallbackRate: rate},
+		})
+		if err == nil {
+			t.Errorf("callback %g%% accepted", rate*100)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// errNoMarginChange is returned when the symbol is already in the requested mode
+const errNoMarginChange = -4046
+
+// GetMarginMode returns the symbol's margin mode
+func (c *Client) GetMarginMode(ctx context.Context, symbol string) (market.MarginMode, error) {
+	raw, err := c.positionRisk(ctx, symbol)
+	if err != nil {
+		return "", err
+	}
+	if len(raw) == 0 {
+		return "", fmt.Errorf("unknown symbol: %s", symbol)
+	}
+
+	return market.ParseMarginMode(raw[0].MarginType)
+}
+
+// SetMarginMode switches a symbol between isolated and cross margin.
+// Binance refuses while the symbol has a position or open orders.
+func (c *Client) SetMarginMode(ctx context.Context, symbol string, mode market.MarginMode) error {
+	marginType := "ISOLATED"
+	if mode == market.MarginCross {
+		marginType = "CROSSED"
+	}
+
+	params := url.Values{}
+	params.Set("symbol", toExchangeSymbol(symbol))
+	params.Set("marginType", marginType)
+
+	err := c.signed(ctx, http.MethodPost, "/fapi/v1/marginType", params, nil)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == errNoMarginChange {
+		return nil
+	}
+	return err
+}
+
+// AdjustIsolatedMargin adds or removes margin on an isolated position.
+// Positions are one-way, so the side is implied by the symbol.
+func (c *Client) AdjustIsolatedMargin(ctx context.Context, symbol string, side broker.Side, amount float64) error {
+	adjustType := "1" // add
+	if amount < 0 {
+		adjustType = "2" // reduce
+	}
+
+	params := url.Values{}
+	params.Set("symbol", toExchangeSymbol(symbol))
+	params.Set("amount", formatFloat(math.Abs(amount)))
+	params.Set("type", adjustType)
+
+	return c.signed(ctx, http.MethodPost, "/fapi/v1/positionMargin", params, nil)
+}
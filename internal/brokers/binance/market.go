@@ -0,0 +1,120 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+)
+
+// GetKlines returns up to limit candles for a symbol, oldest first
+func (c *Client) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]market.Kline, error) {
+	params := url.Values{}
+	params.Set("symbol", toExchangeSymbol(symbol))
+	params.Set("interval", interval)
+	params.Set("limit", strconv.Itoa(limit))
+
+	// Each kline is a positional array: [openTime, open, high, low, close, volume, ...]
+	var raw [][]json.RawMessage
+	if err := c.public(ctx, http.MethodGet, "/fapi/v1/klines", params, &raw); err != nil {
+		return nil, err
+	}
+
+	klines := make([]market.Kline, 0, len(raw))
+	for _, row := range raw {
+		if len(row) < 6 {
+			return nil, fmt.Errorf("malformed kline: %d fields", len(row))
+		}
+
+		var openTime int64
+		if err := json.Unmarshal(row[0], &openTime); err != nil {
+			return nil, fmt.Errorf("malformed kline time: %w", err)
+		}
+
+		values := make([]float64, 5)
+		for i := range values {
+			var s string
+			if err := json.Unmarshal(row[i+1], &s); err != nil {
+				return nil, fmt.Errorf("malformed kline value: %w", err)
+			}
+			values[i] = parseFloat(s)
+		}
+
+		klines = append(klines, market.Kline{
+			OpenTime: time.UnixMilli(openTime),
+			Open:     values[0],
+			High:     values[1],
+			Low:      values[2],
+			Close:    values[3],
+			Volume:   values[4],
+		})
+	}
+
+	return klines, nil
+}
+
+type exchangeInfoResponse struct {
+	Symbols []struct {
+		Symbol       string            `json:"symbol"`
+		Status       string            `json:"status"`
+		ContractType string            `json:"contractType"`
+		BaseAsset    string            `json:"baseAsset"`
+		QuoteAsset   string            `json:"quoteAsset"`
+		RawFilters   []json.RawMessage `json:"filters"`
+	} `json:"symbols"`
+}
+
+type symbolFilter struct {
+	FilterType string `json:"filterType"`
+	TickSize   string `json:"tickSize"`
+	StepSize   string `json:"stepSize"`
+	MinQty     string `json:"minQty"`
+	Notional   string `json:"notional"`
+}
+
+// GetSymbols returns trading rules for every perpetual contract being traded
+func (c *Client) GetSymbols(ctx context.Context) ([]market.SymbolInfo, error) {
+	var resp exchangeInfoResponse
+	if err := c.public(ctx, http.MethodGet, "/fapi/v1/exchangeInfo", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	symbols := make([]market.SymbolInfo, 0, len(resp.Symbols))
+	for _, s := range resp.Symbols {
+		if s.Status != "TRADING" || s.ContractType != "PERPETUAL" {
+			continue
+		}
+
+		info := market.SymbolInfo{
+			Symbol:     s.BaseAsset + "-" + s.QuoteAsset,
+			BaseAsset:  s.BaseAsset,
+			QuoteAsset: s.QuoteAsset,
+		}
+
+		// Filters mix numeric and string fields, so decode each leniently
+		for _, raw := range s.RawFilters {
+			var f symbolFilter
+			if err := json.Unmarshal(raw, &f); err != nil {
+				continue
+			}
+			switch f.FilterType {
+			case "PRICE_FILTER":
+				info.TickSize = parseFloat(f.TickSize)
+			case "LOT_SIZE":
+				info.StepSize = parseFloat(f.StepSize)
+				info.MinQty = parseFloat(f.MinQty)
+			case "MIN_NOTIONAL":
+				info.MinNotional = parseFloat(f.Notional)
+			}
+		}
+
+		symbols = append(symbols, info)
+	}
+
+	return symbols, nil
+}
@@ -0,0 +1,445 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// ErrUnprotectedPosition means a bracket leg was rejected and the entry
+// could not be undone, so the position is open without its stop loss or
+// take profit
+var ErrUnprotectedPosition = errors.New("position left without protection")
+
+// errUnknownOrder is returned for orders Binance no longer knows, such as
+// canceled orders that never filled once they age out
+const errUnknownOrder = -2013
+
+// errCancelRejected is returned when canceling an order that isn't open
+const errCancelRejected = -2011
+
+// Bracket client order IDs tie legs to their entry: the entry is
+// "tc-<id>" and its legs "tc-<id>-sl" and "tc-<id>-tp"
+const (
+	bracketPrefix   = "tc-"
+	stopLegSuffix   = "-sl"
+	targetLegSuffix = "-tp"
+)
+
+type orderResponse struct {
+	OrderID       int64  `json:"orderId"`
+	ClientOrderID string `json:"clientOrderId"`
+	Symbol        string `json:"symbol"`
+	Status        string `json:"status"`
+	Price         string `json:"price"`
+	OrigQty       string `json:"origQty"`
+	ExecutedQty   string `json:"executedQty"`
+	Type          string `json:"type"`
+	Side          string `json:"side"`
+	StopPrice     string `json:"stopPrice"`
+	ReduceOnly    bool   `json:"reduceOnly"`
+	ActivatePrice string `json:"activatePrice"`
+}
+
+// GetOrders returns open orders, optionally filtered by symbol
+func (c *Client) GetOrders(ctx context.Context, filter *broker.OrderFilter) ([]*broker.Order, error) {
+	params := url.Values{}
+	if filter != nil && filter.Symbol != "" {
+		params.Set("symbol", toExchangeSymbol(filter.Symbol))
+	}
+
+	var raw []orderResponse
+	if err := c.signed(ctx, http.MethodGet, "/fapi/v1/openOrders", params, &raw); err != nil {
+		return nil, err
+	}
+
+	orders := make([]*broker.Order, 0, len(raw))
+	for _, o := range raw {
+		orders = append(orders, toOrder(o))
+	}
+
+	return orders, nil
+}
+
+// PlaceOrder places an order. Binance has no attached TP/SL, so bracket
+// legs are placed as separate reduce-only conditional orders, tagged with
+// the entry's client order ID so CancelOrder and GetStaleLegs can find
+// them once the entry is gone. If a leg is rejected the entry is rolled
+// back: a resting entry is canceled and any filled quantity is closed at
+// market. When that fails too, the error wraps ErrUnprotectedPosition.
+func (c *Client) PlaceOrder(ctx context.Context, req *broker.OrderRequest) (*broker.Order, error) {
+	params, err := orderParams(req)
+	if err != nil {
+		return nil, err
+	}
+
+	bracket := bracketLegs(req, bracketPrefix+strconv.FormatInt(c.now().UnixNano(), 36))
+	if len(bracket) > 0 {
+		params.Set("newClientOrderId", bracket[0].entryID)
+	}
+
+	entry, err := c.submit(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	legs := []*broker.Order{}
+	for _, leg := range bracket {
+		order, err := c.submit(ctx, leg.params)
+		if err != nil {
+			return c.rollbackEntry(ctx, req, entry, legs, fmt.Errorf("%s rejected: %w", leg.name, err))
+		}
+		legs = append(legs, toOrder(order))
+	}
+
+	return toOrder(entry), nil
+}
+
+// rollbackEntry undoes an entry whose bracket leg was rejected. Legs
+// already placed are canceled, a resting entry is canceled, and whatever
+// filled is closed with a reduce-only market order, since canceling can't
+// undo a fill.
+func (c *Client) rollbackEntry(ctx context.Context, req *broker.OrderRequest, entry orderResponse, legs []*broker.Order, cause error) (*broker.Order, error) {
+	for i := len(legs) - 1; i >= 0; i-- {
+		if _, err := c.cancel(ctx, req.Symbol, legs[i].ID); err != nil {
+			cause = fmt.Errorf("%w; %s %s could not be canceled: %v", cause, legs[i].Type, legs[i].ID, err)
+		}
+	}
+
+	filled := parseFloat(entry.ExecutedQty)
+	if entry.Status == "NEW" || entry.Status == "PARTIALLY_FILLED" {
+		canceled, err := c.cancel(ctx, req.Symbol, strconv.FormatInt(entry.OrderID, 10))
+		if err != nil {
+			return toOrder(entry), fmt.Errorf("%w: %w; entry %d could not be canceled: %v", ErrUnprotectedPosition, cause, entry.OrderID, err)
+		}
+		filled = parseFloat(canceled.ExecutedQty)
+	}
+	if filled == 0 {
+		return nil, fmt.Errorf("%w; entry canceled", cause)
+	}
+
+	closeSide := broker.SideLong
+	if req.Side == broker.SideLong {
+		closeSide = broker.SideShort
+	}
+	params := url.Values{}
+	params.Set("symbol", toExchangeSymbol(req.Symbol))
+	params.Set("side", exchangeSide(closeSide))
+	params.Set("type", "MARKET")
+	params.Set("quantity", formatFloat(filled))
+	params.Set("reduceOnly", "true")
+	if _, err := c.submit(ctx, params); err != nil {
+		return toOrder(entry), fmt.Errorf("%w: %w; closing the filled %s failed: %v", ErrUnprotectedPosition, cause, formatFloat(filled), err)
+	}
+
+	return nil, fmt.Errorf("%w; filled entry closed at market", cause)
+}
+
+// CancelOrder cancels a single order by ID. Canceling a bracket entry
+// that hasn't filled also cancels its SL/TP legs, which would otherwise
+// close a later position in the symbol.
+func (c *Client) CancelOrder(ctx context.Context, symbol, orderID string) error {
+	canceled, err := c.cancel(ctx, symbol, orderID)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(canceled.ClientOrderID, bracketPrefix) || parseFloat(canceled.ExecutedQty) > 0 {
+		return nil
+	}
+
+	for _, suffix := range []string{stopLegSuffix, targetLegSuffix} {
+		params := url.Values{}
+		params.Set("symbol", toExchangeSymbol(symbol))
+		params.Set("origClientOrderId", canceled.ClientOrderID+suffix)
+		err := c.signed(ctx, http.MethodDelete, "/fapi/v1/order", params, nil)
+
+		var apiErr *APIError
+		if err != nil && !(errors.As(err, &apiErr) && (apiErr.Code == errCancelRejected || apiErr.Code == errUnknownOrder)) {
+			return fmt.Errorf("entry %s canceled, but its leg could not be: %w", orderID, err)
+		}
+	}
+	return nil
+}
+
+// GetStaleLegs lists bracket legs whose entry was canceled or expired
+// without filling. They are still on the book and would close a later,
+// unrelated position in the same symbol.
+func (c *Client) GetStaleLegs(ctx context.Context) ([]*broker.Order, error) {
+	var raw []orderResponse
+	if err := c.signed(ctx, http.MethodGet, "/fapi/v1/openOrders", url.Values{}, &raw); err != nil {
+		return nil, err
+	}
+
+	open := make(map[string]bool, len(raw))
+	for _, o := range raw {
+		open[o.ClientOrderID] = true
+	}
+
+	stale := []*broker.Order{}
+	checked := make(map[string]bool) // Entry ID -> left the book unfilled
+	for _, o := range raw {
+		entryID, ok := bracketEntryID(o.ClientOrderID)
+		if !ok || open[entryID] {
+			continue
+		}
+
+		unfilled, seen := checked[entryID]
+		if !seen {
+			var err error
+			if unfilled, err = c.unfilled(ctx, o.Symbol, entryID); err != nil {
+				return nil, err
+			}
+			checked[entryID] = unfilled
+		}
+		if unfilled {
+			stale = append(stale, toOrder(o))
+		}
+	}
+	return stale, nil
+}
+
+// unfilled reports whether a closed entry left the book without filling.
+// Binance forgets such orders after a few days, so an unknown entry counts
+// as unfilled.
+func (c *Client) unfilled(ctx context.Context, symbol, clientOrderID string) (bool, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("origClientOrderId", clientOrderID)
+
+	var entry orderResponse
+	err := c.signed(ctx, http.MethodGet, "/fapi/v1/order", params, &entry)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == errUnknownOrder {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return entry.Status != "FILLED" && entry.Status != "PARTIALLY_FILLED" && parseFloat(entry.ExecutedQty) == 0, nil
+}
+
+// bracketEntryID returns the entry client order ID of a bracket leg
+func bracketEntryID(clientOrderID string) (string, bool) {
+	if !strings.HasPrefix(clientOrderID, bracketPrefix) {
+		return "", false
+	}
+	for _, suffix := range []string{stopLegSuffix, targetLegSuffix} {
+		if entryID, ok := strings.CutSuffix(clientOrderID, suffix); ok {
+			return entryID, true
+		}
+	}
+	return "", false
+}
+
+// cancel cancels an order and returns its final state, including the
+// quantity that filled before the cancel
+func (c *Client) cancel(ctx context.Context, symbol, orderID string) (orderResponse, error) {
+	params := url.Values{}
+	params.Set("symbol", toExchangeSymbol(symbol))
+	params.Set("orderId", orderID)
+
+	var resp orderResponse
+	err := c.signed(ctx, http.MethodDelete, "/fapi/v1/order", params, &resp)
+	return resp, err
+}
+
+// CancelAllOrders cancels every open order for a symbol
+func (c *Client) CancelAllOrders(ctx context.Context, symbol string) error {
+	params := url.Values{}
+	params.Set("symbol", toExchangeSymbol(symbol))
+
+	return c.signed(ctx, http.MethodDelete, "/fapi/v1/allOpenOrders", params, nil)
+}
+
+func (c *Client) submit(ctx context.Context, params url.Values) (orderResponse, error) {
+	params.Set("newOrderRespType", "RESULT")
+
+	var resp orderResponse
+	err := c.signed(ctx, http.MethodPost, "/fapi/v1/order", params, &resp)
+	return resp, err
+}
+
+// orderParams translates a normalized order request into Binance parameters
+func orderParams(req *broker.OrderRequest) (url.Values, error) {
+	params := url.Values{}
+	params.Set("symbol", toExchangeSymbol(req.Symbol))
+	params.Set("side", exchangeSide(req.Side))
+	params.Set("quantity", formatFloat(req.Size))
+
+	switch req.Type {
+	case broker.OrderTypeMarket:
+		params.Set("type", "MARKET")
+	case broker.OrderTypeLimit:
+		params.Set("type", "LIMIT")
+		params.Set("price", formatFloat(req.Price))
+		params.Set("timeInForce", "GTC")
+	case broker.OrderTypeStop:
+		setConditional(params, "STOP", req.StopPrice, req.Price)
+	case broker.OrderTypeTakeProfit:
+		setConditional(params, "TAKE_PROFIT", req.StopPrice, req.Price)
+	case broker.OrderTypeTrailingStop:
+		if req.Trailing == nil {
+			return nil, fmt.Errorf("trailing stop requires trailing config")
+		}
+		params.Set("type", "TRAILING_STOP_MARKET")
+		if req.Trailing.ActivationPrice > 0 {
+			params.Set("activationPrice", formatFloat(req.Trailing.ActivationPrice))
+		}
+		callback, err := callbackRate(req.Trailing.CallbackRate)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("callbackRate", callback)
+	default:
+		return nil, fmt.Errorf("unsupported order type: %s", req.Type)
+	}
+
+	if req.ReduceOnly {
+		params.Set("reduceOnly", "true")
+	}
+
+	return params, nil
+}
+
+// callbackRate renders a trailing callback (a fraction, 0.01 = 1%) as the
+// percentage Binance expects. Binance accepts 0.1% to 5% in 0.1% steps;
+// anything else is rejected rather than rounded.
+func callbackRate(rate float64) (string, error) {
+	tenths := rate * 1000
+	steps := math.Round(tenths)
+	if math.Abs(tenths-steps) > 1e-6 || steps < 1 || steps > 50 {
+		return "", fmt.Errorf("trailing callback must be 0.1%% to 5%% in 0.1%% steps, got %g%%", rate*100)
+	}
+	return strconv.FormatFloat(steps/10, 'f', 1, 64), nil
+}
+
+// setConditional configures a stop/take-profit order, using the market
+// variant when no limit price is given
+func setConditional(params url.Values, orderType string, triggerPrice, limitPrice float64) {
+	if limitPrice > 0 {
+		params.Set("type", orderType)
+		params.Set("price", formatFloat(limitPrice))
+		params.Set("timeInForce", "GTC")
+	} else {
+		params.Set("type", orderType+"_MARKET")
+	}
+	params.Set("stopPrice", formatFloat(triggerPrice))
+}
+
+type bracketLeg struct {
+	name    string
+	entryID string // Client order ID of the entry
+	params  url.Values
+}
+
+// bracketLegs builds the reduce-only SL/TP orders attached to an entry
+// tagged entryID
+func bracketLegs(req *broker.OrderRequest, entryID string) []bracketLeg {
+	closeSide := broker.SideShort
+	if req.Side == broker.SideShort {
+		closeSide = broker.SideLong
+	}
+
+	legs := make([]bracketLeg, 0, 2)
+
+	if req.StopLoss != nil {
+		params := url.Values{}
+		params.Set("symbol", toExchangeSymbol(req.Symbol))
+		params.Set("side", exchangeSide(closeSide))
+		params.Set("quantity", formatFloat(req.Size))
+		params.Set("reduceOnly", "true")
+		params.Set("workingType", workingType(req.StopLoss.WorkingType))
+		params.Set("newClientOrderId", entryID+stopLegSuffix)
+		setConditional(params, "STOP", req.StopLoss.TriggerPrice, req.StopLoss.OrderPrice)
+		legs = append(legs, bracketLeg{name: "stop loss", entryID: entryID, params: params})
+	}
+
+	if req.TakeProfit != nil {
+		params := url.Values{}
+		params.Set("symbol", toExchangeSymbol(req.Symbol))
+		params.Set("side", exchangeSide(closeSide))
+		params.Set("quantity", formatFloat(req.Size))
+		params.Set("reduceOnly", "true")
+		params.Set("workingType", workingType(req.TakeProfit.WorkingType))
+		params.Set("newClientOrderId", entryID+targetLegSuffix)
+		setConditional(params, "TAKE_PROFIT", req.TakeProfit.TriggerPrice, req.TakeProfit.OrderPrice)
+		legs = append(legs, bracketLeg{name: "take profit", entryID: entryID, params: params})
+	}
+
+	return legs
+}
+
+func exchangeSide(side broker.Side) string {
+	if side == broker.SideShort {
+		return "SELL"
+	}
+	return "BUY"
+}
+
+func workingType(wt broker.WorkingType) string {
+	if wt == broker.WorkingTypeMark {
+		return "MARK_PRICE"
+	}
+	return "CONTRACT_PRICE"
+}
+
+func toOrder(o orderResponse) *broker.Order {
+	side := broker.SideLong
+	if o.Side == "SELL" {
+		side = broker.SideShort
+	}
+
+	var orderType broker.OrderType
+	switch o.Type {
+	case "MARKET":
+		orderType = broker.OrderTypeMarket
+	case "LIMIT":
+		orderType = broker.OrderTypeLimit
+	case "STOP", "STOP_MARKET":
+		orderType = broker.OrderTypeStop
+	case "TAKE_PROFIT", "TAKE_PROFIT_MARKET":
+		orderType = broker.OrderTypeTakeProfit
+	case "TRAILING_STOP_MARKET":
+		orderType = broker.OrderTypeTrailingStop
+	default:
+		orderType = broker.OrderType(o.Type)
+	}
+
+	var status broker.OrderStatus
+	switch o.Status {
+	case "NEW", "PARTIALLY_FILLED":
+		status = broker.OrderStatusNew
+	case "FILLED":
+		status = broker.OrderStatusFilled
+	case "CANCELED", "EXPIRED":
+		status = broker.OrderStatusCanceled
+	case "REJECTED":
+		status = broker.OrderStatusRejected
+	default:
+		status = broker.OrderStatus(o.Status)
+	}
+
+	stopPrice := parseFloat(o.StopPrice)
+	if orderType == broker.OrderTypeTrailingStop && stopPrice == 0 {
+		stopPrice = parseFloat(o.ActivatePrice)
+	}
+
+	return &broker.Order{
+		ID:         strconv.FormatInt(o.OrderID, 10),
+		Symbol:     fromExchangeSymbol(o.Symbol),
+		Side:       side,
+		Type:       orderType,
+		Size:       parseFloat(o.OrigQty),
+		Price:      parseFloat(o.Price),
+		StopPrice:  stopPrice,
+		Status:     status,
+		ReduceOnly: o.ReduceOnly,
+	}
+}
@@ -0,0 +1,164 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+const (
+	// userTradesWindow is the widest startTime/endTime span userTrades accepts
+	userTradesWindow = 7 * 24 * time.Hour
+	userTradesLimit  = 1000
+	incomeLimit      = 1000
+)
+
+type userTradeResponse struct {
+	Symbol          string `json:"symbol"`
+	ID              int64  `json:"id"`
+	OrderID         int64  `json:"orderId"`
+	Side            string `json:"side"`
+	PositionSide    string `json:"positionSide"`
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	RealizedPnl     string `json:"realizedPnl"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Time            int64  `json:"time"`
+}
+
+type incomeResponse struct {
+	Symbol string `json:"symbol"`
+	Time   int64  `json:"time"`
+}
+
+// GetFills returns the account's executions between from and to, oldest
+// first. Without a symbol, the symbols traded are found through the
+// commission entries of the income history.
+func (c *Client) GetFills(ctx context.Context, symbol string, from, to time.Time) ([]market.Fill, error) {
+	symbols := []string{symbol}
+	if symbol == "" {
+		var err error
+		if symbols, err = c.tradedSymbols(ctx, from, to); err != nil {
+			return nil, err
+		}
+	}
+
+	fills := []market.Fill{}
+	for _, s := range symbols {
+		symbolFills, err := c.userTrades(ctx, s, from, to)
+		if err != nil {
+			return nil, err
+		}
+		fills = append(fills, symbolFills...)
+	}
+
+	sort.SliceStable(fills, func(i, j int) bool {
+		return fills[i].Time.Before(fills[j].Time)
+	})
+	return fills, nil
+}
+
+// userTrades pages through one symbol's trades in windows userTrades accepts
+func (c *Client) userTrades(ctx context.Context, symbol string, from, to time.Time) ([]market.Fill, error) {
+	fills := []market.Fill{}
+
+	for start := from; start.Before(to); {
+		end := start.Add(userTradesWindow)
+		if end.After(to) {
+			end = to
+		}
+
+		params := url.Values{}
+		params.Set("symbol", toExchangeSymbol(symbol))
+		params.Set("startTime", strconv.FormatInt(start.UnixMilli(), 10))
+		params.Set("endTime", strconv.FormatInt(end.UnixMilli(), 10))
+		params.Set("limit", strconv.Itoa(userTradesLimit))
+
+		var raw []userTradeResponse
+		if err := c.signed(ctx, http.MethodGet, "/fapi/v1/userTrades", params, &raw); err != nil {
+			return nil, err
+		}
+		for _, t := range raw {
+			fills = append(fills, fillFromResponse(t))
+		}
+
+		// A full page may have more trades in the same window
+		if len(raw) == userTradesLimit {
+			start = time.UnixMilli(raw[len(raw)-1].Time + 1)
+			continue
+		}
+		start = end
+	}
+
+	return fills, nil
+}
+
+// tradedSymbols lists the symbols with commission charged in the range
+func (c *Client) tradedSymbols(ctx context.Context, from, to time.Time) ([]string, error) {
+	seen := make(map[string]bool)
+	symbols := []string{}
+
+	for start := from; start.Before(to); {
+		params := url.Values{}
+		params.Set("incomeType", "COMMISSION")
+		params.Set("startTime", strconv.FormatInt(start.UnixMilli(), 10))
+		params.Set("endTime", strconv.FormatInt(to.UnixMilli(), 10))
+		params.Set("limit", strconv.Itoa(incomeLimit))
+
+		var raw []incomeResponse
+		if err := c.signed(ctx, http.MethodGet, "/fapi/v1/income", params, &raw); err != nil {
+			return nil, err
+		}
+		for _, income := range raw {
+			symbol := fromExchangeSymbol(income.Symbol)
+			if income.Symbol != "" && !seen[symbol] {
+				seen[symbol] = true
+				symbols = append(symbols, symbol)
+			}
+		}
+
+		if len(raw) < incomeLimit {
+			break
+		}
+		start = time.UnixMilli(raw[len(raw)-1].Time + 1)
+	}
+
+	sort.Strings(symbols)
+	return symbols, nil
+}
+
+func fillFromResponse(t userTradeResponse) market.Fill {
+	side := broker.SideLong
+	if t.Side == "SELL" {
+		side = broker.SideShort
+	}
+
+	var positionSide broker.Side
+	switch t.PositionSide {
+	case "LONG":
+		positionSide = broker.SideLong
+	case "SHORT":
+		positionSide = broker.SideShort
+	}
+
+	return market.Fill{
+		ID:           strconv.FormatInt(t.ID, 10),
+		OrderID:      strconv.FormatInt(t.OrderID, 10),
+		Symbol:       fromExchangeSymbol(t.Symbol),
+		Side:         side,
+		PositionSide: positionSide,
+		Price:        parseFloat(t.Price),
+		Quantity:     parseFloat(t.Qty),
+		Fee:          parseFloat(t.Commission),
+		FeeAsset:     t.CommissionAsset,
+		RealizedPnL:  parseFloat(t.RealizedPnl),
+		Time:         time.UnixMilli(t.Time),
+	}
+}
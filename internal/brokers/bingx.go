@@ -0,0 +1,85 @@
+package brokers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/brokers/bingxapi"
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-go/bingx"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// bingxClient extends the trading-go client with endpoints it doesn't cover
+type bingxClient struct {
+	*bingx.Client
+	api *bingxapi.Client
+}
+
+func init() {
+	Register(Registration{
+		Name:        "bingx",
+		Description: "BingX perpetual futures",
+		Fields: []Field{
+			{Name: "base_url", Description: "override the REST endpoint for market data, fills and margin calls"},
+			{Name: "recv_window", Description: "signed request validity window in ms"},
+		},
+		Capabilities: Capabilities{
+			Demo:          true,
+			BracketOrders: true,
+			TrailingStop:  true,
+		},
+		New: func(settings Settings) (broker.Broker, error) {
+			opts := []bingxapi.Option{}
+
+			if baseURL := settings.Option("base_url", ""); baseURL != "" {
+				opts = append(opts, bingxapi.WithBaseURL(baseURL))
+			}
+
+			if raw := settings.Option("recv_window", ""); raw != "" {
+				ms, err := strconv.ParseInt(raw, 10, 64)
+				if err != nil || ms <= 0 {
+					return nil, fmt.Errorf("invalid recv_window: %s", raw)
+				}
+				opts = append(opts, bingxapi.WithRecvWindow(ms))
+			}
+
+			return &bingxClient{
+				Client: bingx.NewClient(settings.APIKey, settings.SecretKey, settings.Demo),
+				api:    bingxapi.NewClient(settings.APIKey, settings.SecretKey, settings.Demo, opts...),
+			}, nil
+		},
+	})
+}
+
+// GetKlines implements KlineProvider
+func (c *bingxClient) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]market.Kline, error) {
+	return c.api.GetKlines(ctx, symbol, interval, limit)
+}
+
+// GetSymbols implements SymbolInfoProvider
+func (c *bingxClient) GetSymbols(ctx context.Context) ([]market.SymbolInfo, error) {
+	return c.api.GetSymbols(ctx)
+}
+
+// GetFills implements FillProvider
+func (c *bingxClient) GetFills(ctx context.Context, symbol string, from, to time.Time) ([]market.Fill, error) {
+	return c.api.GetFills(ctx, symbol, from, to)
+}
+
+// GetMarginMode implements MarginController
+func (c *bingxClient) GetMarginMode(ctx context.Context, symbol string) (market.MarginMode, error) {
+	return c.api.GetMarginMode(ctx, symbol)
+}
+
+// SetMarginMode implements MarginController
+func (c *bingxClient) SetMarginMode(ctx context.Context, symbol string, mode market.MarginMode) error {
+	return c.api.SetMarginMode(ctx, symbol, mode)
+}
+
+// AdjustIsolatedMargin implements MarginController
+func (c *bingxClient) AdjustIsolatedMargin(ctx context.Context, symbol string, side broker.Side, amount float64) error {
+	return c.api.AdjustIsolatedMargin(ctx, symbol, side, amount)
+}
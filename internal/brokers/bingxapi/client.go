@@ -0,0 +1,149 @@
+// Package bingxapi covers BingX perpetual futures endpoints that the
+// trading-go client does not expose, such as market data and margin settings.
+package bingxapi
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ProductionURL is the BingX REST endpoint
+	ProductionURL = "https://open-api.bingx.com"
+	// DemoURL is the BingX virtual (VST) trading endpoint
+	DemoURL = "https://open-api-vst.bingx.com"
+)
+
+// Client is a minimal BingX REST client
+type Client struct {
+	apiKey     string
+	secretKey  string
+	baseURL    string
+	recvWindow int64 // Zero leaves the exchange default
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// Option customizes a Client
+type Option func(*Client)
+
+// WithBaseURL overrides the REST endpoint (useful for proxies and test stubs)
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithRecvWindow sets the signed request validity window in milliseconds
+func WithRecvWindow(ms int64) Option {
+	return func(c *Client) {
+		c.recvWindow = ms
+	}
+}
+
+// NewClient creates a BingX REST client
+func NewClient(apiKey, secretKey string, demo bool, opts ...Option) *Client {
+	baseURL := ProductionURL
+	if demo {
+		baseURL = DemoURL
+	}
+
+	c := &Client{
+		apiKey:     apiKey,
+		secretKey:  secretKey,
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		now:        time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// envelope is the standard BingX response wrapper
+type envelope struct {
+	Code int             `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+// public performs an unsigned GET and decodes the data field into out
+func (c *Client) public(ctx context.Context, path string, params url.Values, out interface{}) error {
+	endpoint := c.baseURL + path
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	return c.send(req, out)
+}
+
+// signed performs a request authenticated with the account keys
+func (c *Client) signed(ctx context.Context, method, path string, params url.Values, out interface{}) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("timestamp", strconv.FormatInt(c.now().UnixMilli(), 10))
+	if c.recvWindow > 0 {
+		params.Set("recvWindow", strconv.FormatInt(c.recvWindow, 10))
+	}
+
+	query := params.Encode()
+	mac := hmac.New(sha256.New, []byte(c.secretKey))
+	mac.Write([]byte(query))
+	query += "&signature=" + hex.EncodeToString(mac.Sum(nil))
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path+"?"+query, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("X-BX-APIKEY", c.apiKey)
+
+	return c.send(req, out)
+}
+
+func (c *Client) send(req *http.Request, out interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return fmt.Errorf("bingx: unexpected response (http %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if env.Code != 0 {
+		return fmt.Errorf("bingx: %s (code %d)", env.Msg, env.Code)
+	}
+
+	if out == nil || len(env.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(env.Data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package bingxapi
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// GetMarginMode returns the symbol's margin mode
+func (c *Client) GetMarginMode(ctx context.Context, symbol string) (market.MarginMode, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	var resp struct {
+		MarginType string `json:"marginType"`
+	}
+	if err := c.signed(ctx, http.MethodGet, "/openApi/swap/v2/trade/marginType", params, &resp); err != nil {
+		return "", err
+	}
+
+	return market.ParseMarginMode(resp.MarginType)
+}
+
+// SetMarginMode switches a symbol between isolated and cross margin
+func (c *Client) SetMarginMode(ctx context.Context, symbol string, mode market.MarginMode) error {
+	marginType := "ISOLATED"
+	if mode == market.MarginCross {
+		marginType = "CROSSED"
+	}
+
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("marginType", marginType)
+
+	return c.signed(ctx, http.MethodPost, "/openApi/swap/v2/trade/marginType", params, nil)
+}
+
+// AdjustIsolatedMargin adds (amount > 0) or removes (amount < 0) margin on
+// an isolated position
+func (c *Client) AdjustIsolatedMargin(ctx context.Context, symbol string, side broker.Side, amount float64) error {
+	adjustType := "1" // add
+	if amount < 0 {
+		adjustType = "2" // reduce
+	}
+
+	positionSide := "LONG"
+	if side == broker.SideShort {
+		positionSide = "SHORT"
+	}
+
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("amount", strconv.FormatFloat(math.Abs(amount), 'f', -1, 64))
+	params.Set("type", adjustType)
+	params.Set("positionSide", positionSide)
+
+	return c.signed(ctx, http.MethodPost, "/openApi/swap/v2/trade/positionMargin", params, nil)
+}
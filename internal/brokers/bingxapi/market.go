@@ -0,0 +1,98 @@
+package bingxapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+)
+
+type klineResponse struct {
+	Open   json.Number `json:"open"`
+	High   json.Number `json:"high"`
+	Low    json.Number `json:"low"`
+	Close  json.Number `json:"close"`
+	Volume json.Number `json:"volume"`
+	Time   int64       `json:"time"`
+}
+
+// GetKlines returns up to limit candles for a symbol, oldest first
+func (c *Client) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]market.Kline, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("interval", interval)
+	params.Set("limit", strconv.Itoa(limit))
+
+	var raw []klineResponse
+	if err := c.public(ctx, "/openApi/swap/v3/quote/klines", params, &raw); err != nil {
+		return nil, err
+	}
+
+	klines := make([]market.Kline, 0, len(raw))
+	for _, k := range raw {
+		open, _ := k.Open.Float64()
+		high, _ := k.High.Float64()
+		low, _ := k.Low.Float64()
+		closePrice, _ := k.Close.Float64()
+		volume, _ := k.Volume.Float64()
+
+		klines = append(klines, market.Kline{
+			OpenTime: time.UnixMilli(k.Time),
+			Open:     open,
+			High:     high,
+			Low:      low,
+			Close:    closePrice,
+			Volume:   volume,
+		})
+	}
+
+	// BingX returns newest first
+	sort.Slice(klines, func(i, j int) bool {
+		return klines[i].OpenTime.Before(klines[j].OpenTime)
+	})
+
+	return klines, nil
+}
+
+type contractResponse struct {
+	Symbol            string  `json:"symbol"`
+	Asset             string  `json:"asset"`
+	Currency          string  `json:"currency"`
+	PricePrecision    int     `json:"pricePrecision"`
+	QuantityPrecision int     `json:"quantityPrecision"`
+	TradeMinQuantity  float64 `json:"tradeMinQuantity"`
+	TradeMinUSDT      float64 `json:"tradeMinUSDT"`
+	Status            int     `json:"status"`
+}
+
+// GetSymbols returns trading rules for every listed perpetual contract
+func (c *Client) GetSymbols(ctx context.Context) ([]market.SymbolInfo, error) {
+	var raw []contractResponse
+	if err := c.public(ctx, "/openApi/swap/v2/quote/contracts", nil, &raw); err != nil {
+		return nil, err
+	}
+
+	symbols := make([]market.SymbolInfo, 0, len(raw))
+	for _, contract := range raw {
+		// Status 1 is online; anything else is paused or delisted
+		if contract.Status != 1 {
+			continue
+		}
+
+		symbols = append(symbols, market.SymbolInfo{
+			Symbol:      contract.Symbol,
+			BaseAsset:   contract.Asset,
+			QuoteAsset:  contract.Currency,
+			TickSize:    market.DecimalsToStep(contract.PricePrecision),
+			StepSize:    market.DecimalsToStep(contract.QuantityPrecision),
+			MinQty:      contract.TradeMinQuantity,
+			MinNotional: contract.TradeMinUSDT,
+		})
+	}
+
+	return symbols, nil
+}
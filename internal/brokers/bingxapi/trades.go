@@ -0,0 +1,124 @@
+package bingxapi
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+const (
+	// fillHistoryWindow is the widest startTs/endTs span fillHistory accepts
+	fillHistoryWindow   = 7 * 24 * time.Hour
+	fillHistoryPageSize = 1000
+)
+
+type fillResponse struct {
+	Symbol          string      `json:"symbol"`
+	TradeID         string      `json:"tradeId"`
+	OrderID         string      `json:"orderId"`
+	Side            string      `json:"side"`
+	PositionSide    string      `json:"positionSide"`
+	Price           json.Number `json:"price"`
+	Qty             json.Number `json:"qty"`
+	Commission      json.Number `json:"commission"`
+	CommissionAsset string      `json:"commissionAsset"`
+	RealisedPNL     json.Number `json:"realisedPNL"`
+	FilledTm        string      `json:"filledTm"`
+}
+
+// GetFills returns the account's executions between from and to, oldest
+// first, for one symbol or all of them
+func (c *Client) GetFills(ctx context.Context, symbol string, from, to time.Time) ([]market.Fill, error) {
+	fills := []market.Fill{}
+
+	for start := from; start.Before(to); start = start.Add(fillHistoryWindow) {
+		end := start.Add(fillHistoryWindow)
+		if end.After(to) {
+			end = to
+		}
+
+		for page := 1; ; page++ {
+			params := url.Values{}
+			if symbol != "" {
+				params.Set("symbol", symbol)
+			}
+			params.Set("startTs", strconv.FormatInt(start.UnixMilli(), 10))
+			params.Set("endTs", strconv.FormatInt(end.UnixMilli(), 10))
+			params.Set("pageIndex", strconv.Itoa(page))
+			params.Set("pageSize", strconv.Itoa(fillHistoryPageSize))
+
+			var resp struct {
+				Fills []fillResponse `json:"fill_history_orders"`
+			}
+			if err := c.signed(ctx, http.MethodGet, "/openApi/swap/v2/trade/fillHistory", params, &resp); err != nil {
+				return nil, err
+			}
+			for _, f := range resp.Fills {
+				fills = append(fills, fillFromResponse(f))
+			}
+
+			if len(resp.Fills) < fillHistoryPageSize {
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(fills, func(i, j int) bool {
+		return fills[i].Time.Before(fills[j].Time)
+	})
+	return fills, nil
+}
+
+func fillFromResponse(f fillResponse) market.Fill {
+	side := broker.SideLong
+	if f.Side == "SELL" {
+		side = broker.SideShort
+	}
+
+	var positionSide broker.Side
+	switch f.PositionSide {
+	case "LONG":
+		positionSide = broker.SideLong
+	case "SHORT":
+		positionSide = broker.SideShort
+	}
+
+	price, _ := f.Price.Float64()
+	qty, _ := f.Qty.Float64()
+	commission, _ := f.Commission.Float64()
+	pnl, _ := f.RealisedPNL.Float64()
+
+	return market.Fill{
+		ID:           f.TradeID,
+		OrderID:      f.OrderID,
+		Symbol:       f.Symbol,
+		Side:         side,
+		PositionSide: positionSide,
+		Price:        price,
+		Quantity:     qty,
+		Fee:          math.Abs(commission), // BingX reports fees paid as negative
+		FeeAsset:     f.CommissionAsset,
+		RealizedPnL:  pnl,
+		Time:         parseFillTime(f.FilledTm),
+	}
+}
+
+// parseFillTime reads filledTm, an RFC 3339 timestamp or epoch milliseconds
+func parseFillTime(s string) time.Time {
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms)
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t
+	}
+	t, _ := time.Parse("2006-01-02T15:04:05", s)
+	return t
+}
@@ -0,0 +1,54 @@
+package brokers
+
+import (
+	"context"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// The interfaces below are optional extensions to broker.Broker. Commands
+// that need them type-assert the broker and report when it isn't supported.
+
+// KlineProvider serves historical candles, oldest first
+type KlineProvider interface {
+	GetKlines(ctx context.Context, symbol, interval string, limit int) ([]market.Kline, error)
+}
+
+// OrderCanceler cancels a single order by ID
+type OrderCanceler interface {
+	CancelOrder(ctx context.Context, symbol, orderID string) error
+}
+
+// StaleLegFinder lists SL/TP legs placed for an entry that was canceled
+// or expired without filling. Brokers that place bracket legs as separate
+// orders leave them on the book, where they would close a later position.
+type StaleLegFinder interface {
+	GetStaleLegs(ctx context.Context) ([]*broker.Order, error)
+}
+
+// SymbolInfoProvider lists exchange trading rules (tick size, lot step, minimums)
+type SymbolInfoProvider interface {
+	GetSymbols(ctx context.Context) ([]market.SymbolInfo, error)
+}
+
+// MarginController switches margin mode and moves isolated margin
+type MarginController interface {
+	GetMarginMode(ctx context.Context, symbol string) (market.MarginMode, error)
+	SetMarginMode(ctx context.Context, symbol string, mode market.MarginMode) error
+	// AdjustIsolatedMargin adds (amount > 0) or removes (amount < 0) margin
+	AdjustIsolatedMargin(ctx context.Context, symbol string, side broker.Side, amount float64) error
+}
+
+// PositionRiskProvider reports the exchange's own liquidation price and
+// margin mode for open positions, optionally for one symbol
+type PositionRiskProvider interface {
+	GetPositionRisks(ctx context.Context, symbol string) ([]market.PositionRisk, error)
+}
+
+// FillProvider lists the account's executions in a time range, oldest
+// first. An empty symbol means every symbol traded in the range.
+type FillProvider interface {
+	GetFills(ctx context.Context, symbol string, from, to time.Time) ([]market.Fill, error)
+}
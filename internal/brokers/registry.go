@@ -0,0 +1,130 @@
+package brokers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// Settings carries everything a factory needs to build a broker client
+type Settings struct {
+	APIKey    string
+	SecretKey string
+	Options   map[string]string // Extra per-broker fields from config
+	Demo      bool
+}
+
+// Option returns an extra option value, or the fallback when unset
+func (s Settings) Option(name, fallback string) string {
+	if value, ok := s.Options[name]; ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// Factory builds a broker client for an account
+type Factory func(settings Settings) (broker.Broker, error)
+
+// Field describes an extra configuration option accepted by a broker
+type Field struct {
+	Name        string
+	Description string
+	Required    bool
+}
+
+// Capabilities advertises optional features supported by a broker
+type Capabilities struct {
+	Demo          bool // Demo/testnet environment available
+	BracketOrders bool // TP/SL can be attached to the entry order
+	TrailingStop  bool // Native trailing stop orders
+}
+
+// Registration describes a broker that can be referenced from config
+type Registration struct {
+	Name         string
+	Description  string
+	Fields       []Field
+	Capabilities Capabilities
+	New          Factory
+}
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]*Registration)
+)
+
+// Register makes a broker available by name. It panics on duplicate
+// or incomplete registrations, since those are programming errors.
+func Register(reg Registration) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if reg.Name == "" || reg.New == nil {
+		panic("brokers: registration requires a name and a factory")
+	}
+	if _, exists := registry[reg.Name]; exists {
+		panic("brokers: duplicate registration for " + reg.Name)
+	}
+
+	registry[reg.Name] = &reg
+}
+
+// Lookup returns the registration for a broker name
+func Lookup(name string) (*Registration, error) {
+	mu.RLock()
+	reg, ok := registry[name]
+	mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported broker: %s (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return reg, nil
+}
+
+// Names returns all registered broker names in sorted order
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateOptions checks extra options against the broker's config schema
+func (r *Registration) ValidateOptions(options map[string]string) error {
+	known := make(map[string]bool, len(r.Fields))
+	for _, field := range r.Fields {
+		known[field.Name] = true
+		if field.Required && options[field.Name] == "" {
+			return fmt.Errorf("%s requires option %q (%s)", r.Name, field.Name, field.Description)
+		}
+	}
+
+	for name := range options {
+		if !known[name] {
+			return fmt.Errorf("unknown option %q for broker %s (accepted: %s)", name, r.Name, r.fieldNames())
+		}
+	}
+
+	return nil
+}
+
+// fieldNames lists accepted option names for error messages
+func (r *Registration) fieldNames() string {
+	if len(r.Fields) == 0 {
+		return "none"
+	}
+
+	names := make([]string, len(r.Fields))
+	for i, field := range r.Fields {
+		names[i] = field.Name
+	}
+	return strings.Join(names, ", ")
+}
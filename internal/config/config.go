@@ -3,22 +3,121 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/agatticelli/trading-cli/internal/brokers"
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-cli/internal/sizing"
+	"github.com/agatticelli/trading-cli/internal/strategies"
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the application configuration
 type Config struct {
-	Accounts []Account `yaml:"accounts"`
+	Accounts  []Account       `yaml:"accounts"`
+	Risk      RiskLimits      `yaml:"risk,omitempty"`       // Portfolio limits applied to every account
+	DailyLoss DailyLossLimits `yaml:"daily_loss,omitempty"` // Trading lockout after a losing day
+	Symbols   SymbolSettings  `yaml:"symbols,omitempty"`    // Exchange symbol rules and naming
+	Fees      FeeSettings     `yaml:"fees,omitempty"`       // Fee-aware position sizing
+	Sizing    SizingSettings  `yaml:"sizing,omitempty"`     // Capital positions are sized against
+	ATR       ATRSettings     `yaml:"atr,omitempty"`        // ATR used by atr: stops and targets
+	Mirror    MirrorSettings  `yaml:"mirror,omitempty"`     // Copy trading from a leader account
+	DataDir   string          `yaml:"data_dir,omitempty"`   // Local state directory (default ~/.trading-cli)
+
+	Strategies map[string]StrategyConfig `yaml:"strategies,omitempty"` // Named strategies for open --strategy
+}
+
+// FeeSettings enables fee-aware sizing. Rates are percentages of notional
+// (0.05 = 0.05%); sizing is unchanged when nothing is configured.
+type FeeSettings struct {
+	SlippagePercent      float64             `yaml:"slippage_percent,omitempty"`       // Buffer for stops filling past their trigger
+	FundingBufferPercent float64             `yaml:"funding_buffer_percent,omitempty"` // Reserve for funding paid while open
+	Brokers              map[string]FeeRates `yaml:"brokers,omitempty"`                // Default rates per broker
+}
+
+// FeeRates are an account's maker/taker fees in percent
+type FeeRates struct {
+	MakerPercent float64 `yaml:"maker_percent"` // May be negative (rebate)
+	TakerPercent float64 `yaml:"taker_percent"`
+}
+
+// SizingSettings selects the capital the risk percent is taken from:
+// available balance (default), equity, or a fixed capital amount
+type SizingSettings struct {
+	Base    string  `yaml:"base,omitempty"`    // available, equity or fixed
+	Capital float64 `yaml:"capital,omitempty"` // Required for the fixed base
+}
+
+// ATRSettings configures the ATR behind "atr:<multiple>" stops and targets
+type ATRSettings struct {
+	Period   int    `yaml:"period,omitempty"`   // Candles averaged (default 14)
+	Interval string `yaml:"interval,omitempty"` // Candle interval (default 1h)
+}
+
+// MirrorSettings configures copy trading: followers replicate the leader's
+// positions, stops and take profits, scaled by equity or a multiplier
+type MirrorSettings struct {
+	Leader       string           `yaml:"leader,omitempty"`
+	Followers    []MirrorFollower `yaml:"followers,omitempty"`
+	Interval     string           `yaml:"interval,omitempty"`      // Poll period (default 10s)
+	DriftPercent float64          `yaml:"drift_percent,omitempty"` // Size drift tolerated before resizing (default 5)
+}
+
+// MirrorFollower is an account copying the leader
+type MirrorFollower struct {
+	Account    string  `yaml:"account"`
+	Multiplier float64 `yaml:"multiplier,omitempty"` // Fixed size ratio to the leader (default: equity ratio)
+}
+
+// StrategyConfig declares a named strategy: a registered type plus its
+// numeric parameters, e.g. {type: riskratio, rr: 3}
+type StrategyConfig struct {
+	Type   string             `yaml:"type"`
+	Params map[string]float64 `yaml:",inline"`
+}
+
+// SymbolSettings configures symbol rule caching and how user input is
+// resolved to exchange symbols
+type SymbolSettings struct {
+	CacheTTL     string            `yaml:"cache_ttl,omitempty"`     // e.g. 12h (default 24h)
+	DefaultQuote string            `yaml:"default_quote,omitempty"` // Quote asset when only a base is given (default USDT)
+	Aliases      map[string]string `yaml:"aliases,omitempty"`       // Name -> base asset or full symbol
+}
+
+// DailyLossLimits configures the daily loss lockout. Losses are measured
+// against start-of-day equity; zero values disable a limit.
+type DailyLossLimits struct {
+	MaxLossPercent      float64 `yaml:"max_loss_percent,omitempty"`       // Per account
+	MaxLossUSD          float64 `yaml:"max_loss_usd,omitempty"`           // Per account
+	MaxTotalLossPercent float64 `yaml:"max_total_loss_percent,omitempty"` // Across all accounts
+	MaxTotalLossUSD     float64 `yaml:"max_total_loss_usd,omitempty"`     // Across all accounts
+	ResetHourUTC        int     `yaml:"reset_hour_utc,omitempty"`         // Session boundary (0-23)
+}
+
+// RiskLimits configures the pre-trade risk guard. Zero values disable a limit.
+type RiskLimits struct {
+	MaxPositions       int     `yaml:"max_positions,omitempty"`
+	MaxOpenRiskPercent float64 `yaml:"max_open_risk_percent,omitempty"` // Sum of distance-to-stop × size over equity
+	MaxSymbolNotional  float64 `yaml:"max_symbol_notional,omitempty"`
+	MaxLeverage        int     `yaml:"max_leverage,omitempty"`
+
+	// Maintenance margin rate used for liquidation estimates (default 0.5)
+	MaintenanceMarginPercent float64 `yaml:"maintenance_margin_percent,omitempty"`
 }
 
 // Account represents a trading account configuration
 type Account struct {
-	Name      string `yaml:"name"`
-	APIKey    string `yaml:"api_key"`
-	SecretKey string `yaml:"secret_key"`
-	Broker    string `yaml:"broker"`
-	Enabled   bool   `yaml:"enabled"`
+	Name       string            `yaml:"name"`
+	APIKey     string            `yaml:"api_key"`
+	SecretKey  string            `yaml:"secret_key"`
+	Broker     string            `yaml:"broker"`
+	Options    map[string]string `yaml:"options,omitempty"`     // Broker-specific fields (passphrase, base_url, ...)
+	Risk       *RiskLimits       `yaml:"risk,omitempty"`        // Overrides the global risk limits
+	Fees       *FeeRates         `yaml:"fees,omitempty"`        // Overrides the broker's fee rates
+	MarginMode string            `yaml:"margin_mode,omitempty"` // Default for open: isolated or cross
+	Sizing     *SizingSettings   `yaml:"sizing,omitempty"`      // Overrides the global sizing base
+	Enabled    bool              `yaml:"enabled"`
 }
 
 // Load reads and parses the configuration file
@@ -53,6 +152,196 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if err := c.Risk.Validate(); err != nil {
+		return fmt.Errorf("risk: %w", err)
+	}
+
+	if err := c.DailyLoss.Validate(); err != nil {
+		return fmt.Errorf("daily_loss: %w", err)
+	}
+
+	if err := c.Symbols.Validate(); err != nil {
+		return fmt.Errorf("symbols: %w", err)
+	}
+
+	if err := c.Fees.Validate(); err != nil {
+		return fmt.Errorf("fees: %w", err)
+	}
+
+	if err := c.Sizing.Validate(); err != nil {
+		return fmt.Errorf("sizing: %w", err)
+	}
+
+	if c.ATR.Period < 0 {
+		return fmt.Errorf("atr: period must not be negative")
+	}
+
+	if err := c.Mirror.Validate(c.Accounts); err != nil {
+		return fmt.Errorf("mirror: %w", err)
+	}
+
+	for name, strat := range c.Strategies {
+		if err := strat.Validate(); err != nil {
+			return fmt.Errorf("strategy %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that daily loss limits are sane
+func (d *DailyLossLimits) Validate() error {
+	if d.MaxLossPercent < 0 || d.MaxLossUSD < 0 || d.MaxTotalLossPercent < 0 || d.MaxTotalLossUSD < 0 {
+		return fmt.Errorf("limits must not be negative")
+	}
+	if d.ResetHourUTC < 0 || d.ResetHourUTC > 23 {
+		return fmt.Errorf("reset_hour_utc must be between 0 and 23")
+	}
+	return nil
+}
+
+// Enabled reports whether any daily loss limit is configured
+func (d *DailyLossLimits) Enabled() bool {
+	return d.MaxLossPercent > 0 || d.MaxLossUSD > 0 || d.MaxTotalLossPercent > 0 || d.MaxTotalLossUSD > 0
+}
+
+// Validate checks that fee settings are plausible percentages
+func (f *FeeSettings) Validate() error {
+	if f.SlippagePercent < 0 || f.SlippagePercent >= 10 {
+		return fmt.Errorf("slippage_percent must be between 0 and 10")
+	}
+	if f.FundingBufferPercent < 0 || f.FundingBufferPercent >= 10 {
+		return fmt.Errorf("funding_buffer_percent must be between 0 and 10")
+	}
+	for name, rates := range f.Brokers {
+		if _, err := brokers.Lookup(name); err != nil {
+			return err
+		}
+		if err := rates.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that fee rates are plausible percentages
+func (r *FeeRates) Validate() error {
+	if r.TakerPercent < 0 || r.TakerPercent >= 10 || r.MakerPercent <= -10 || r.MakerPercent >= 10 {
+		return fmt.Errorf("fee rates must be percentages below 10 (0.05 = 0.05%%)")
+	}
+	return nil
+}
+
+// Validate checks that the sizing base is known and fixed capital is set
+func (s *SizingSettings) Validate() error {
+	if s.Capital < 0 {
+		return fmt.Errorf("capital must not be negative")
+	}
+	switch s.Base {
+	case "", string(sizing.BaseAvailable), string(sizing.BaseEquity):
+		return nil
+	case string(sizing.BaseFixed):
+		if s.Capital == 0 {
+			return fmt.Errorf("base fixed requires capital")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown base %q (use available, equity or fixed)", s.Base)
+	}
+}
+
+// Validate checks that the strategy type is registered and its parameters
+// build a strategy
+func (s *StrategyConfig) Validate() error {
+	reg, err := strategies.Lookup(s.Type)
+	if err != nil {
+		return err
+	}
+	_, err = reg.Build(s.Params)
+	return err
+}
+
+// Validate checks that mirror accounts exist and settings parse
+func (m *MirrorSettings) Validate(accounts []Account) error {
+	known := make(map[string]bool, len(accounts))
+	for _, account := range accounts {
+		known[account.Name] = true
+	}
+
+	if m.Leader != "" && !known[m.Leader] {
+		return fmt.Errorf("unknown leader account %q", m.Leader)
+	}
+	for _, follower := range m.Followers {
+		if !known[follower.Account] {
+			return fmt.Errorf("unknown follower account %q", follower.Account)
+		}
+		if follower.Account == m.Leader {
+			return fmt.Errorf("%s cannot follow itself", follower.Account)
+		}
+		if follower.Multiplier < 0 {
+			return fmt.Errorf("%s: multiplier must not be negative", follower.Account)
+		}
+	}
+
+	if m.DriftPercent < 0 || m.DriftPercent >= 100 {
+		return fmt.Errorf("drift_percent must be between 0 and 100")
+	}
+	if m.Interval != "" {
+		interval, err := time.ParseDuration(m.Interval)
+		if err != nil {
+			return fmt.Errorf("invalid interval: %w", err)
+		}
+		if interval < time.Second {
+			return fmt.Errorf("interval must be at least 1s")
+		}
+	}
+	return nil
+}
+
+// PollInterval returns the configured poll period, or zero for the default
+func (m *MirrorSettings) PollInterval() time.Duration {
+	interval, _ := time.ParseDuration(m.Interval)
+	return interval
+}
+
+// Validate checks that symbol settings parse
+func (s *SymbolSettings) Validate() error {
+	for name, target := range s.Aliases {
+		if strings.TrimSpace(name) == "" || strings.TrimSpace(target) == "" {
+			return fmt.Errorf("aliases must map a name to a symbol")
+		}
+	}
+
+	if s.CacheTTL == "" {
+		return nil
+	}
+	ttl, err := time.ParseDuration(s.CacheTTL)
+	if err != nil {
+		return fmt.Errorf("invalid cache_ttl: %w", err)
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("cache_ttl must be positive")
+	}
+	return nil
+}
+
+// TTL returns the configured cache lifetime, or zero for the default
+func (s *SymbolSettings) TTL() time.Duration {
+	ttl, _ := time.ParseDuration(s.CacheTTL)
+	return ttl
+}
+
+// Validate checks that risk limits are not negative
+func (r *RiskLimits) Validate() error {
+	if r.MaxPositions < 0 || r.MaxOpenRiskPercent < 0 || r.MaxSymbolNotional < 0 || r.MaxLeverage < 0 {
+		return fmt.Errorf("limits must not be negative")
+	}
+	if r.MaxOpenRiskPercent > 100 {
+		return fmt.Errorf("max_open_risk_percent must be at most 100")
+	}
+	if r.MaintenanceMarginPercent < 0 || r.MaintenanceMarginPercent >= 50 {
+		return fmt.Errorf("maintenance_margin_percent must be between 0 and 50")
+	}
 	return nil
 }
 
@@ -74,16 +363,37 @@ func (a *Account) Validate() error {
 		return fmt.Errorf("broker is required")
 	}
 
-	// Validate broker is supported
-	supportedBrokers := map[string]bool{
-		"bingx": true,
+	if a.Risk != nil {
+		if err := a.Risk.Validate(); err != nil {
+			return fmt.Errorf("risk: %w", err)
+		}
 	}
 
-	if !supportedBrokers[a.Broker] {
-		return fmt.Errorf("unsupported broker: %s", a.Broker)
+	if a.Fees != nil {
+		if err := a.Fees.Validate(); err != nil {
+			return fmt.Errorf("fees: %w", err)
+		}
 	}
 
-	return nil
+	if a.Sizing != nil {
+		if err := a.Sizing.Validate(); err != nil {
+			return fmt.Errorf("sizing: %w", err)
+		}
+	}
+
+	if a.MarginMode != "" {
+		if _, err := market.ParseMarginMode(a.MarginMode); err != nil {
+			return err
+		}
+	}
+
+	// Validate broker is registered and its extra options match the schema
+	reg, err := brokers.Lookup(a.Broker)
+	if err != nil {
+		return err
+	}
+
+	return reg.ValidateOptions(a.Options)
 }
 
 // GetEnabledAccounts returns only enabled accounts
@@ -106,3 +416,67 @@ func (c *Config) GetAccountByName(name string) (*Account, error) {
 	}
 	return nil, fmt.Errorf("account not found: %s", name)
 }
+
+// RiskLimitsFor returns the global risk limits with any account overrides applied
+func (c *Config) RiskLimitsFor(accountName string) RiskLimits {
+	limits := c.Risk
+
+	account, err := c.GetAccountByName(accountName)
+	if err != nil || account.Risk == nil {
+		return limits
+	}
+
+	if account.Risk.MaxPositions > 0 {
+		limits.MaxPositions = account.Risk.MaxPositions
+	}
+	if account.Risk.MaxOpenRiskPercent > 0 {
+		limits.MaxOpenRiskPercent = account.Risk.MaxOpenRiskPercent
+	}
+	if account.Risk.MaxSymbolNotional > 0 {
+		limits.MaxSymbolNotional = account.Risk.MaxSymbolNotional
+	}
+	if account.Risk.MaxLeverage > 0 {
+		limits.MaxLeverage = account.Risk.MaxLeverage
+	}
+	if account.Risk.MaintenanceMarginPercent > 0 {
+		limits.MaintenanceMarginPercent = account.Risk.MaintenanceMarginPercent
+	}
+
+	return limits
+}
+
+// FeeRatesFor returns an account's fee rates: its own override, else its
+// broker's default. ok is false when neither is configured.
+func (c *Config) FeeRatesFor(accountName string) (rates FeeRates, ok bool) {
+	account, err := c.GetAccountByName(accountName)
+	if err != nil {
+		return FeeRates{}, false
+	}
+
+	if account.Fees != nil {
+		return *account.Fees, true
+	}
+
+	rates, ok = c.Fees.Brokers[account.Broker]
+	return rates, ok
+}
+
+// SizingFor returns the global sizing settings with any account override
+// applied. An account capital alone keeps the global base.
+func (c *Config) SizingFor(accountName string) SizingSettings {
+	settings := c.Sizing
+
+	account, err := c.GetAccountByName(accountName)
+	if err != nil || account.Sizing == nil {
+		return settings
+	}
+
+	if account.Sizing.Base != "" {
+		settings.Base = account.Sizing.Base
+	}
+	if account.Sizing.Capital > 0 {
+		settings.Capital = account.Sizing.Capital
+	}
+
+	return settings
+}
@@ -0,0 +1,145 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/agatticelli/intent-go"
+	"github.com/agatticelli/trading-cli/internal/risk"
+	"github.com/agatticelli/trading-cli/internal/sizing"
+	"github.com/agatticelli/trading-cli/internal/ui"
+)
+
+// AllocateEquity splits an allocated trade by account equity
+const AllocateEquity = "equity"
+
+// allocateOpen sizes a trade once for all accounts together and splits
+// it by equity or by the weights in opts.Allocate. Each share is sized with
+// the account's fees and lot step up front, so the allocation table shows
+// the quantities that are placed; it returns them as fixed sizes.
+func (e *Executor) allocateOpen(ctx context.Context, cmd *intent.NormalizedCommand, opts OpenOptions, request sizing.Request, daily *risk.DailyStatus) (map[string]sizing.Request, error) {
+	byEquity := opts.Allocate == AllocateEquity
+	weights := make(map[string]float64)
+	if !byEquity {
+		parsed, err := sizing.ParseWeights(opts.Allocate)
+		if err != nil {
+			return nil, err
+		}
+		for name := range parsed {
+			if _, ok := e.brokers[name]; !ok {
+				return nil, fmt.Errorf("allocation weight for an account that is not selected: %s", name)
+			}
+		}
+		weights = parsed
+	}
+
+	// Resolve every account's prices; the first account's are the
+	// reference notional and quantity totals are computed from
+	notes := make(map[string]string)
+	prices := make(map[string]*tradeLevels)
+	currentPrices := make(map[string]float64)
+	var reference *tradeLevels
+	var capital float64
+	for _, accountName := range e.AccountNames() {
+		if _, ok := weights[accountName]; !ok && !byEquity {
+			weights[accountName] = 0
+			continue
+		}
+		brk := e.brokers[accountName]
+
+		if daily != nil && !opts.Override {
+			if reason := daily.Blocked(accountName); reason != "" {
+				notes[accountName] = "locked: " + reason
+				continue
+			}
+		}
+
+		balance, err := brk.GetBalance(ctx)
+		if err != nil {
+			notes[accountName] = fmt.Sprintf("balance unavailable: %v", err)
+			continue
+		}
+		currentPrice, err := brk.GetCurrentPrice(ctx, cmd.Symbol)
+		if err != nil {
+			notes[accountName] = fmt.Sprintf("price unavailable: %v", err)
+			continue
+		}
+		levels, err := e.resolveLevels(ctx, brk, cmd, opts, currentPrice)
+		if err != nil {
+			notes[accountName] = err.Error()
+			continue
+		}
+		if request.Model == sizing.ModelRiskPercent {
+			_, amount, err := e.sizingBase(accountName, balance, opts.SizingBase)
+			if err != nil {
+				notes[accountName] = err.Error()
+				continue
+			}
+			capital += amount
+		}
+
+		if byEquity {
+			weights[accountName] = balance.Total + balance.UnrealizedPnL
+		}
+		prices[accountName] = levels
+		currentPrices[accountName] = currentPrice
+		if reference == nil {
+			reference = levels
+		}
+	}
+	if reference == nil {
+		return nil, fmt.Errorf("no account can take the trade")
+	}
+
+	// Risk is split as a budget, sized per account net of its fees;
+	// notional and quantity are split as a size
+	var budget, total float64
+	switch request.Model {
+	case sizing.ModelRiskPercent:
+		budget = request.Value / 100 * capital
+	case sizing.ModelRiskUSD:
+		budget = request.Value
+	case sizing.ModelNotional:
+		if reference.Entry <= 0 {
+			return nil, fmt.Errorf("entry price is required for notional sizing")
+		}
+		total = request.Value / reference.Entry
+	case sizing.ModelQuantity:
+		total = request.Value
+	default:
+		return nil, fmt.Errorf("unknown sizing model: %s", request.Model)
+	}
+
+	allocations := sizing.Allocate(weights, notes, func(accountName string, share float64) float64 {
+		levels := prices[accountName]
+		size := share * total
+		if !request.FixedSize() {
+			fees := e.feesFor(accountName, *cmd.Side, levels.Entry, currentPrices[accountName])
+			size = fees.SizeFor(share*budget, levels.Entry, levels.Stop)
+		}
+
+		info, err := e.symbolInfo(ctx, accountName, e.brokers[accountName], cmd.Symbol)
+		if err != nil || info == nil {
+			return size // Reported when the account's plan is built
+		}
+		size = info.RoundSize(size)
+		if info.CheckMinimums(size, levels.Entry) != nil {
+			return 0
+		}
+		return size
+	})
+
+	fmt.Print(ui.FormatAllocation(cmd.Symbol, byEquity, request, reference.Entry, reference.Stop, allocations))
+
+	requests := make(map[string]sizing.Request)
+	for _, allocation := range allocations {
+		if allocation.Allocated() {
+			requests[allocation.Account] = sizing.Request{Model: sizing.ModelQuantity, Value: allocation.Size}
+		}
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("no account can take its share of the trade")
+	}
+
+	return requests, nil
+}
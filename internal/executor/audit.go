@@ -0,0 +1,324 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/agatticelli/trading-cli/internal/brokers"
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-cli/internal/risk"
+	"github.com/agatticelli/trading-cli/internal/ui"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// AuditOptions controls how ExecuteAudit repairs what it finds
+type AuditOptions struct {
+	Fix         bool    // Attach default stops and cancel orphaned orders
+	StopPercent float64 // Default stop distance from entry, in percent
+	StopATR     float64 // Default stop distance in ATR multiples (takes precedence)
+	ATRPeriod   int     // For --stop-atr (default: config, else 14)
+	ATRInterval string  // For --stop-atr (default: config, else 1h)
+}
+
+// auditFinding is one problem found on an account
+type auditFinding struct {
+	symbol   string
+	issue    string
+	detail   string
+	action   string
+	critical bool
+}
+
+// ExecuteAudit scans every account for unprotected positions, mismatched
+// stop sizes and orphaned reduce-only orders, optionally fixing them
+func (e *Executor) ExecuteAudit(ctx context.Context, opts AuditOptions) error {
+	total := 0
+
+	for accountName, brk := range e.brokers {
+		fmt.Println(ui.Account(accountName))
+		e.loadPrecision(ctx, accountName, brk)
+
+		positions, err := brk.GetPositions(ctx, &broker.PositionFilter{})
+		if err != nil {
+			fmt.Println(ui.Error(fmt.Sprintf("Failed to get positions: %v", err)))
+			continue
+		}
+
+		orders, err := brk.GetOrders(ctx, &broker.OrderFilter{})
+		if err != nil {
+			fmt.Println(ui.Error(fmt.Sprintf("Failed to get orders: %v", err)))
+			continue
+		}
+
+		findings := e.auditAccount(ctx, accountName, brk, positions, orders, opts)
+		total += len(findings)
+
+		if len(findings) == 0 {
+			fmt.Println(ui.Success(fmt.Sprintf("%d position(s), %d order(s): all protected", len(positions), len(orders))))
+			continue
+		}
+
+		fmt.Println(formatAuditFindings(findings))
+	}
+
+	if total > 0 && !opts.Fix {
+		fmt.Println(ui.MutedStyle.Render("\nRe-run with --fix to attach default stops and cancel orphaned orders"))
+	}
+
+	return nil
+}
+
+// auditAccount checks one account using the same symbol/type order lookup
+// as the positions table
+func (e *Executor) auditAccount(ctx context.Context, accountName string, brk broker.Broker, positions []*broker.Position, orders []*broker.Order, opts AuditOptions) []auditFinding {
+	findings := []auditFinding{}
+
+	// Legs of entries that never filled protect nothing; set them aside
+	// so they don't pass for a position's stop
+	if finder, ok := brk.(brokers.StaleLegFinder); ok {
+		stale, err := finder.GetStaleLegs(ctx)
+		if err != nil {
+			findings = append(findings, auditFinding{
+				symbol: "-",
+				issue:  "Stale leg check failed",
+				detail: err.Error(),
+				action: "-",
+			})
+		}
+		orders, findings = auditStaleLegs(ctx, brk, orders, stale, findings, opts)
+	}
+	orderMap := risk.OrdersBySymbol(orders)
+
+	positionSymbols := make(map[string]bool)
+	for _, pos := range positions {
+		positionSymbols[pos.Symbol] = true
+		byType := orderMap[pos.Symbol]
+
+		// A trailing stop protects the position as well as a fixed one
+		stop := byType[broker.OrderTypeStop]
+		if stop == nil {
+			stop = byType[broker.OrderTypeTrailingStop]
+		}
+		if stop == nil {
+			finding := auditFinding{
+				symbol:   pos.Symbol,
+				issue:    "No stop loss",
+				detail:   fmt.Sprintf("%s %s @ %s", pos.Side, ui.FormatSize(pos.Symbol, pos.Size), formatPrice(pos.Symbol, pos.EntryPrice)),
+				action:   "-",
+				critical: true,
+			}
+			if opts.Fix {
+				finding.action = e.attachDefaultStop(ctx, accountName, brk, pos, opts)
+			}
+			findings = append(findings, finding)
+		} else if stop.Size > 0 && math.Abs(stop.Size-pos.Size) > pos.Size*sizeTolerance {
+			findings = append(findings, auditFinding{
+				symbol:   pos.Symbol,
+				issue:    "Stop size mismatch",
+				detail:   fmt.Sprintf("stop %s vs position %s", ui.FormatSize(pos.Symbol, stop.Size), ui.FormatSize(pos.Symbol, pos.Size)),
+				action:   "-",
+				critical: stop.Size < pos.Size,
+			})
+		}
+
+		if byType[broker.OrderTypeTakeProfit] == nil {
+			findings = append(findings, auditFinding{
+				symbol: pos.Symbol,
+				issue:  "No take profit",
+				detail: fmt.Sprintf("%s %s @ %s", pos.Side, ui.FormatSize(pos.Symbol, pos.Size), formatPrice(pos.Symbol, pos.EntryPrice)),
+				action: "-",
+			})
+		}
+	}
+
+	// Orphans: closing orders on symbols with neither a position nor a
+	// pending entry they could be attached to
+	orphansBySymbol := make(map[string][]*broker.Order)
+	pendingEntry := make(map[string]bool)
+	for _, order := range orders {
+		if !isClosingOrder(order) {
+			pendingEntry[order.Symbol] = true
+		}
+	}
+	for _, order := range orders {
+		if isClosingOrder(order) && !positionSymbols[order.Symbol] && !pendingEntry[order.Symbol] {
+			orphansBySymbol[order.Symbol] = append(orphansBySymbol[order.Symbol], order)
+		}
+	}
+
+	for symbol, orphans := range orphansBySymbol {
+		action := "-"
+		if opts.Fix {
+			action = cancelOrphans(ctx, brk, symbol, orphans)
+		}
+		for _, order := range orphans {
+			findings = append(findings, auditFinding{
+				symbol: symbol,
+				issue:  "Orphaned order",
+				detail: fmt.Sprintf("%s %s @ %s (no position)", order.Type, order.ID, formatPrice(symbol, risk.TriggerPrice(order))),
+				action: action,
+			})
+		}
+	}
+
+	return findings
+}
+
+// auditStaleLegs reports bracket legs left by unfilled entries, canceling
+// them by ID with --fix, and returns the orders without them
+func auditStaleLegs(ctx context.Context, brk broker.Broker, orders, stale []*broker.Order, findings []auditFinding, opts AuditOptions) ([]*broker.Order, []auditFinding) {
+	if len(stale) == 0 {
+		return orders, findings
+	}
+
+	staleIDs := make(map[string]bool, len(stale))
+	for _, order := range stale {
+		staleIDs[order.ID] = true
+
+		action := "-"
+		if opts.Fix {
+			action = ui.WarningStyle.Render("skipped: broker can't cancel single orders")
+			if canceler, ok := brk.(brokers.OrderCanceler); ok {
+				action = ui.SuccessStyle.Render("canceled")
+				if err := canceler.CancelOrder(ctx, order.Symbol, order.ID); err != nil {
+					action = ui.ErrorStyle.Render(fmt.Sprintf("failed: %v", err))
+				}
+			}
+		}
+		findings = append(findings, auditFinding{
+			symbol:   order.Symbol,
+			issue:    "Stale bracket leg",
+			detail:   fmt.Sprintf("%s %s @ %s (entry canceled unfilled)", order.Type, order.ID, formatPrice(order.Symbol, risk.TriggerPrice(order))),
+			action:   action,
+			critical: true,
+		})
+	}
+
+	kept := make([]*broker.Order, 0, len(orders))
+	for _, order := range orders {
+		if !staleIDs[order.ID] {
+			kept = append(kept, order)
+		}
+	}
+	return kept, findings
+}
+
+// isClosingOrder reports whether an order can only reduce a position
+func isClosingOrder(order *broker.Order) bool {
+	if order.ReduceOnly {
+		return true
+	}
+	switch order.Type {
+	case broker.OrderTypeStop, broker.OrderTypeTakeProfit, broker.OrderTypeTrailingStop:
+		return true
+	}
+	return false
+}
+
+// attachDefaultStop places a reduce-only stop at the configured default
+// distance and describes the outcome
+func (e *Executor) attachDefaultStop(ctx context.Context, accountName string, brk broker.Broker, pos *broker.Position, opts AuditOptions) string {
+	distance := 0.0
+	source := ""
+
+	switch {
+	case opts.StopATR > 0:
+		period, interval := e.atrSettings(opts.ATRPeriod, opts.ATRInterval)
+		atr, err := e.fetchATR(ctx, brk, pos.Symbol, interval, period)
+		if err != nil {
+			return ui.ErrorStyle.Render(fmt.Sprintf("skipped: %v", err))
+		}
+		distance = atr * opts.StopATR
+		source = fmt.Sprintf("%.1f×ATR", opts.StopATR)
+	case opts.StopPercent > 0:
+		distance = pos.EntryPrice * opts.StopPercent / 100
+		source = fmt.Sprintf("%.2f%%", opts.StopPercent)
+	default:
+		return ui.WarningStyle.Render("skipped: pass --stop-pct or --stop-atr")
+	}
+
+	stopPrice := pos.EntryPrice - distance
+	stopSide := broker.SideShort
+	if pos.Side == broker.SideShort {
+		stopPrice = pos.EntryPrice + distance
+		stopSide = broker.SideLong
+	}
+	if info, err := e.symbolInfo(ctx, accountName, brk, pos.Symbol); err == nil && info != nil {
+		stopPrice = info.RoundPrice(stopPrice)
+	}
+
+	// A stop on the wrong side of mark would fire immediately
+	if pos.MarkPrice > 0 && risk.LossAtPrice(pos.Side, pos.MarkPrice, stopPrice, pos.Size) <= 0 {
+		return ui.WarningStyle.Render(fmt.Sprintf("skipped: stop %s already crossed by mark %s",
+			formatPrice(pos.Symbol, stopPrice), formatPrice(pos.Symbol, pos.MarkPrice)))
+	}
+
+	_, err := brk.PlaceOrder(ctx, &broker.OrderRequest{
+		Symbol:     pos.Symbol,
+		Side:       stopSide,
+		Type:       broker.OrderTypeStop,
+		Size:       pos.Size,
+		StopPrice:  stopPrice,
+		ReduceOnly: true,
+	})
+	if err != nil {
+		return ui.ErrorStyle.Render(fmt.Sprintf("failed: %v", err))
+	}
+
+	return ui.SuccessStyle.Render(fmt.Sprintf("stop placed at %s (%s)", formatPrice(pos.Symbol, stopPrice), source))
+}
+
+// fetchATR computes ATR for a symbol from the broker's candles
+func (e *Executor) fetchATR(ctx context.Context, brk broker.Broker, symbol, interval string, period int) (float64, error) {
+	provider, ok := brk.(brokers.KlineProvider)
+	if !ok {
+		return 0, fmt.Errorf("broker does not provide candle data")
+	}
+
+	klines, err := provider.GetKlines(ctx, symbol, interval, period*3+1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get candles: %w", err)
+	}
+
+	return market.ATR(klines, period)
+}
+
+// cancelOrphans cancels orphaned orders, by ID when the broker supports it
+func cancelOrphans(ctx context.Context, brk broker.Broker, symbol string, orphans []*broker.Order) string {
+	if canceler, ok := brk.(brokers.OrderCanceler); ok {
+		for _, order := range orphans {
+			if err := canceler.CancelOrder(ctx, symbol, order.ID); err != nil {
+				return ui.ErrorStyle.Render(fmt.Sprintf("failed: %v", err))
+			}
+		}
+		return ui.SuccessStyle.Render("canceled")
+	}
+
+	// Every order on this symbol is an orphan, so canceling them all is safe
+	if err := brk.CancelAllOrders(ctx, symbol); err != nil {
+		return ui.ErrorStyle.Render(fmt.Sprintf("failed: %v", err))
+	}
+	return ui.SuccessStyle.Render("canceled")
+}
+
+// formatAuditFindings renders findings as a table
+func formatAuditFindings(findings []auditFinding) string {
+	table := ui.NewTable("Symbol", "Issue", "Detail", "Action")
+
+	for _, f := range findings {
+		issue := ui.WarningStyle.Render(f.issue)
+		if f.critical {
+			issue = ui.ErrorStyle.Render(f.issue)
+		}
+
+		table.AddRow(
+			ui.BoldStyle.Render(f.symbol),
+			issue,
+			f.detail,
+			f.action,
+		)
+	}
+
+	return table.Render()
+}
@@ -0,0 +1,130 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/agatticelli/calculator-go"
+	"github.com/agatticelli/strategy-go"
+	"github.com/agatticelli/trading-cli/internal/risk"
+	"github.com/agatticelli/trading-cli/internal/sizing"
+	"github.com/agatticelli/trading-cli/internal/strategies"
+	types "github.com/agatticelli/trading-common-types"
+)
+
+// CalcParams are the inputs to an offline position calculation
+type CalcParams struct {
+	Symbol          string // Optional; only used for price precision
+	Side            strategy.Side
+	Balance         float64
+	Base            sizing.Base // What Balance is, for the plan (default: available)
+	Entry           float64
+	StopLoss        float64
+	TakeProfit      float64 // Optional; overrides the RR target
+	RiskPercent     float64
+	RR              float64           // For the default strategy, when Strategy is nil
+	Strategy        strategy.Strategy // Optional; a named strategy from CalcStrategy
+	StrategyLabel   string            // Describes Strategy in the plan
+	MaintenanceRate float64           // Default: risk.DefaultMaintenanceRate
+}
+
+// Calculate prints the position plan for a trade using the same
+// calculator and strategy code as open, without a broker or config (a
+// named strategy comes from CalcStrategy). The cross liquidation assumes
+// the balance backs only this position.
+func Calculate(ctx context.Context, p CalcParams) error {
+	if p.Balance <= 0 {
+		return fmt.Errorf("balance must be positive")
+	}
+	if p.MaintenanceRate <= 0 {
+		p.MaintenanceRate = risk.DefaultMaintenanceRate
+	}
+
+	calc := calculator.New(125)
+	if err := calc.ValidateStopLoss(p.Side, p.Entry, p.StopLoss); err != nil {
+		return fmt.Errorf("invalid stop loss: %w", err)
+	}
+
+	strat := p.Strategy
+	if strat == nil {
+		reg, err := strategies.Lookup(DefaultStrategy)
+		if err != nil {
+			return err
+		}
+		if strat, err = reg.Build(map[string]float64{"rr": p.RR}); err != nil {
+			return err
+		}
+	}
+
+	plan, err := strat.CalculatePosition(ctx, strategy.PositionParams{
+		Symbol:         p.Symbol,
+		Side:           p.Side,
+		EntryPrice:     p.Entry,
+		StopLoss:       p.StopLoss,
+		AccountBalance: p.Balance,
+		RiskPercent:    p.RiskPercent,
+		MaxLeverage:    125,
+	})
+	if err != nil {
+		return fmt.Errorf("position calculation failed: %w", err)
+	}
+	if p.TakeProfit > 0 {
+		plan.TakeProfits = []*types.TakeProfitLevel{{Price: p.TakeProfit}}
+	}
+
+	// Lower leverage until the isolated liquidation lies beyond the stop,
+	// as open would
+	safe, isolated, err := safeLeverage(plan, p.MaintenanceRate)
+	if err != nil {
+		return err
+	}
+	lowerLeverage(plan, safe, isolated)
+
+	// Reward at each target, from the same PnL math the positions view uses
+	rewards := make([]float64, len(plan.TakeProfits))
+	for i, tp := range plan.TakeProfits {
+		rewards[i], _ = calc.CalculateExpectedPnL(plan.Side, plan.EntryPrice, tp.Price, plan.Size)
+	}
+
+	displayPositionPlan(plan, planDetails{
+		Strategy: p.StrategyLabel,
+		Balance:  p.Balance,
+		Base:     p.Base,
+		Sizing:   sizing.Request{Model: sizing.ModelRiskPercent, Value: p.RiskPercent},
+		Liquidation: risk.Liquidation{
+			Isolated: risk.IsolatedLiquidationPrice(plan.Side, plan.EntryPrice, plan.Leverage, p.MaintenanceRate),
+			Cross:    risk.LiquidationPrice(plan.Side, plan.EntryPrice, plan.Size, p.Balance, p.MaintenanceRate),
+		},
+		Rewards: rewards,
+	})
+
+	return nil
+}
+
+// CalcStrategy returns a configured strategy and its description for
+// calc's position plan
+func (e *Executor) CalcStrategy(name string) (strategy.Strategy, string, error) {
+	strat, ok := e.strategies[name]
+	if !ok {
+		return nil, "", fmt.Errorf("strategy not found: %s (available: %s)", name, strings.Join(e.StrategyNames(), ", "))
+	}
+	return strat, e.describeStrategy(name), nil
+}
+
+// SizingCapital returns what an account sizes against under its
+// configured sizing base, for calc
+func (e *Executor) SizingCapital(ctx context.Context, accountName string) (float64, sizing.Base, error) {
+	brk, ok := e.brokers[accountName]
+	if !ok {
+		return 0, "", fmt.Errorf("account not found: %s", accountName)
+	}
+
+	balance, err := brk.GetBalance(ctx)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	base, capital, err := e.sizingBase(accountName, balance, "")
+	return capital, base, err
+}
@@ -0,0 +1,49 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/agatticelli/trading-cli/internal/history"
+	"github.com/agatticelli/trading-cli/internal/snapshots"
+	"github.com/agatticelli/trading-cli/internal/ui"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// recordSnapshot stores an account's balance for the equity curve
+func (e *Executor) recordSnapshot(accountName string, balance *broker.Balance) {
+	if _, err := e.snapshots.Record(snapshots.FromBalance(accountName, balance)); err != nil {
+		fmt.Println(ui.Warning(fmt.Sprintf("Failed to record equity snapshot: %v", err)))
+	}
+}
+
+// ExecuteBalanceHistory draws each account's equity curve over a period
+// from recorded snapshots, taking a fresh one first
+func (e *Executor) ExecuteBalanceHistory(ctx context.Context, period history.Period) error {
+	for _, accountName := range e.AccountNames() {
+		brk := e.brokers[accountName]
+		fmt.Println(ui.Account(accountName))
+
+		if balance, err := brk.GetBalance(ctx); err != nil {
+			fmt.Println(ui.Warning(fmt.Sprintf("Failed to get balance, showing recorded snapshots only: %v", err)))
+		} else {
+			e.recordSnapshot(accountName, balance)
+		}
+
+		series, err := e.snapshots.Series(accountName, period.From)
+		if err != nil {
+			fmt.Println(ui.Error(fmt.Sprintf("Failed to read equity snapshots: %v", err)))
+			continue
+		}
+
+		// Keep only snapshots up to the end of the period
+		end := len(series)
+		for end > 0 && series[end-1].At.After(period.To) {
+			end--
+		}
+
+		fmt.Print(ui.FormatEquityHistory(series[:end]))
+	}
+
+	return nil
+}
@@ -2,66 +2,247 @@ package executor
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/agatticelli/calculator-go"
 	"github.com/agatticelli/intent-go"
 	"github.com/agatticelli/strategy-go"
-	"github.com/agatticelli/strategy-go/strategies/riskratio"
-	"github.com/agatticelli/trading-go/bingx"
-	"github.com/agatticelli/trading-go/broker"
+	"github.com/agatticelli/trading-cli/internal/brokers"
+	"github.com/agatticelli/trading-cli/internal/brokers/binance"
 	"github.com/agatticelli/trading-cli/internal/config"
+	"github.com/agatticelli/trading-cli/internal/journal"
+	"github.com/agatticelli/trading-cli/internal/levels"
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-cli/internal/risk"
+	"github.com/agatticelli/trading-cli/internal/sizing"
+	"github.com/agatticelli/trading-cli/internal/snapshots"
+	"github.com/agatticelli/trading-cli/internal/store"
+	"github.com/agatticelli/trading-cli/internal/symbols"
 	"github.com/agatticelli/trading-cli/internal/ui"
+	types "github.com/agatticelli/trading-common-types"
+	"github.com/agatticelli/trading-go/broker"
 )
 
 // Executor orchestrates commands across multiple accounts and modules
 type Executor struct {
-	config     *config.Config
-	brokers    map[string]broker.Broker // accountName -> broker
-	strategies map[string]strategy.Strategy
-	calculator *calculator.Calculator
-	isDemoMode bool
+	config       *config.Config
+	brokers      map[string]broker.Broker        // accountName -> broker
+	capabilities map[string]brokers.Capabilities // accountName -> broker capabilities
+	brokerNames  map[string]string               // accountName -> registered broker name
+	strategies   map[string]strategy.Strategy
+	strategyDefs map[string]config.StrategyConfig // strategy name -> type and parameters
+	calculator   *calculator.Calculator
+	store        *store.Store
+	symbols      *symbols.Cache
+	daily        *risk.DailyTracker
+	journal      *journal.Journal
+	snapshots    *snapshots.Log
+	isDemoMode   bool
+}
+
+// DefaultStrategy is used when no strategy is requested
+const DefaultStrategy = "riskratio"
+
+// OpenOptions controls how ExecuteOpenPosition opens a trade
+type OpenOptions struct {
+	Strategy        string            // Strategy name (default: riskratio)
+	Override        bool              // Bypass the daily loss lockout; every use is recorded
+	RetryProtection bool              // Re-attach SL/TP legs missing after placement
+	MarginMode      market.MarginMode // Isolated or cross (default: account setting, else unchanged)
+	Entry           *levels.Expr      // Overrides the command's entry (e.g. mark, -0.3%)
+	StopLoss        *levels.Expr      // Overrides the command's stop (e.g. 1.5%, atr:1.5)
+	TakeProfit      *levels.Expr      // Overrides the command's take profit and the strategy's
+	ATRPeriod       int               // For atr: expressions (default: config, else 14)
+	ATRInterval     string            // For atr: expressions (default: config, else 1h)
+	Sizing          sizing.Request    // Alternative to the command's risk percent
+	SizingBase      string            // available, equity, fixed or an amount (default: config)
+	Allocate        string            // Split one total size by "equity" or weights ("main=2,alt=1")
+}
+
+// highRiskPercent triggers a warning when a dollar, notional or quantity
+// size implies risking more than this share of the balance
+const highRiskPercent = 5.0
+
+// planDetails is what the position plan shows beyond the plan itself
+type planDetails struct {
+	Strategy    string
+	Levels      *tradeLevels
+	Balance     float64
+	Base        sizing.Base
+	Sizing      sizing.Request
+	Fees        *sizing.FeeBreakdown
+	Liquidation risk.Liquidation
+	MarginMode  market.MarginMode
+	Rewards     []float64 // Profit at each take profit, when known
 }
 
 // New creates a new executor
 func New(cfg *config.Config, isDemoMode bool) (*Executor, error) {
 	executor := &Executor{
-		config:     cfg,
-		brokers:    make(map[string]broker.Broker),
-		strategies: make(map[string]strategy.Strategy),
-		calculator: calculator.New(125), // Max leverage 125x
-		isDemoMode: isDemoMode,
+		config:       cfg,
+		brokers:      make(map[string]broker.Broker),
+		capabilities: make(map[string]brokers.Capabilities),
+		brokerNames:  make(map[string]string),
+		strategies:   make(map[string]strategy.Strategy),
+		calculator:   calculator.New(125), // Max leverage 125x
+		isDemoMode:   isDemoMode,
 	}
 
-	// Initialize brokers for each enabled account
+	// Initialize brokers for each enabled account from the registry
 	for _, account := range cfg.GetEnabledAccounts() {
-		switch account.Broker {
-		case "bingx":
-			client := bingx.NewClient(account.APIKey, account.SecretKey, isDemoMode)
-			executor.brokers[account.Name] = client
-		default:
-			return nil, fmt.Errorf("unsupported broker: %s", account.Broker)
+		reg, err := brokers.Lookup(account.Broker)
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", account.Name, err)
 		}
+
+		if isDemoMode && !reg.Capabilities.Demo {
+			return nil, fmt.Errorf("account %s: broker %s does not support demo mode", account.Name, reg.Name)
+		}
+
+		client, err := reg.New(brokers.Settings{
+			APIKey:    account.APIKey,
+			SecretKey: account.SecretKey,
+			Options:   account.Options,
+			Demo:      isDemoMode,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("account %s: failed to create %s client: %w", account.Name, reg.Name, err)
+		}
+
+		executor.brokers[account.Name] = client
+		executor.capabilities[account.Name] = reg.Capabilities
+		executor.brokerNames[account.Name] = reg.Name
 	}
 
-	// Initialize default strategies
-	executor.strategies["riskratio"] = riskratio.New(2.0) // Default 2:1 RR
+	// Open local state store (sessions, snapshots, caches)
+	st, err := store.Open(store.ResolveDir(cfg.DataDir, isDemoMode))
+	if err != nil {
+		return nil, err
+	}
+	executor.store = st
+	executor.daily = risk.NewDailyTracker(st, cfg.DailyLoss)
+	executor.journal = journal.New(st)
+	executor.snapshots = snapshots.New(st)
+	executor.symbols = symbols.NewCache(st, cfg.Symbols.TTL())
+
+	// Initialize strategies: the default 2:1 risk ratio plus any declared
+	// in config, which may also redefine the default
+	if err := executor.loadStrategies(cfg.Strategies); err != nil {
+		return nil, err
+	}
 
 	return executor, nil
 }
 
+// SelectAccounts restricts execution to the named accounts
+func (e *Executor) SelectAccounts(names []string) error {
+	selected := make(map[string]broker.Broker, len(names))
+	for _, name := range names {
+		brk, ok := e.brokers[name]
+		if !ok {
+			return fmt.Errorf("account not found or not enabled: %s", name)
+		}
+		selected[name] = brk
+	}
+
+	e.brokers = selected
+	return nil
+}
+
+// AccountNames returns the selected account names
+func (e *Executor) AccountNames() []string {
+	names := make([]string, 0, len(e.brokers))
+	for name := range e.brokers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // ExecuteOpenPosition opens a position across all accounts
-func (e *Executor) ExecuteOpenPosition(ctx context.Context, cmd *intent.NormalizedCommand, strategyName string) error {
-	// Get strategy
-	strat, ok := e.strategies[strategyName]
+func (e *Executor) ExecuteOpenPosition(ctx context.Context, cmd *intent.NormalizedCommand, opts OpenOptions) error {
+	if opts.Strategy == "" {
+		opts.Strategy = DefaultStrategy
+	}
+
+	// Get strategy; a risk-reward ratio on the command replaces the
+	// default strategy's
+	strat, ok := e.strategies[opts.Strategy]
 	if !ok {
-		return fmt.Errorf("strategy not found: %s", strategyName)
+		return fmt.Errorf("strategy not found: %s (available: %s)", opts.Strategy, strings.Join(e.StrategyNames(), ", "))
+	}
+	if cmd.RRRatio != nil && opts.Strategy == DefaultStrategy {
+		var err error
+		if strat, err = ratioStrategy(*cmd.RRRatio); err != nil {
+			return fmt.Errorf("invalid risk-reward ratio: %w", err)
+		}
+	}
+
+	// Without an explicit sizing model, size by the command's risk percent
+	request := opts.Sizing
+	if request.Model == "" {
+		if cmd.RiskPercent == nil {
+			return fmt.Errorf("risk is required")
+		}
+		request = sizing.Request{Model: sizing.ModelRiskPercent, Value: *cmd.RiskPercent}
+	}
+	if _, _, err := sizing.ParseBase(opts.SizingBase); err != nil {
+		return err
+	}
+	if cmd.EntryPrice == nil && opts.Entry == nil {
+		return fmt.Errorf("entry price is required")
+	}
+	if cmd.StopLoss == nil && opts.StopLoss == nil {
+		return fmt.Errorf("stop loss is required")
+	}
+
+	// Check the daily loss lockout before anything else
+	daily, err := e.observeDailyLoss(ctx)
+	if err != nil {
+		return fmt.Errorf("daily loss check failed: %w", err)
+	}
+
+	// An allocated trade is sized once and split across accounts
+	var allocated map[string]sizing.Request
+	if opts.Allocate != "" {
+		allocated, err = e.allocateOpen(ctx, cmd, opts, request, daily)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Execute for each account
 	for accountName, brk := range e.brokers {
+		request := request
+		if allocated != nil {
+			share, ok := allocated[accountName]
+			if !ok {
+				continue // Shown as left out in the allocation table
+			}
+			request = share
+		}
+
 		fmt.Printf("\n💼 Account: %s\n", accountName)
 
+		if daily != nil {
+			if reason := daily.Blocked(accountName); reason != "" {
+				if !opts.Override {
+					fmt.Printf("  ✗ Trading locked until %s: %s\n", daily.ResetsAt.Local().Format("Jan 2 15:04"), reason)
+					fmt.Printf("    Use --override to bypass (the override is recorded)\n")
+					continue
+				}
+				if err := e.daily.RecordOverride(daily, accountName, "open "+cmd.Symbol); err != nil {
+					fmt.Printf("  ✗ Failed to record override: %v\n", err)
+					continue
+				}
+				fmt.Printf("  ⚠ Daily loss lockout overridden (%s) - recorded\n", reason)
+			}
+		}
+
 		// 1. Get balance
 		balance, err := brk.GetBalance(ctx)
 		if err != nil {
@@ -76,42 +257,134 @@ func (e *Executor) ExecuteOpenPosition(ctx context.Context, cmd *intent.Normaliz
 			continue
 		}
 
-		// 3. Validate price logic using calculator
-		if err := e.calculator.ValidatePriceLogic(*cmd.Side, *cmd.EntryPrice, currentPrice); err != nil {
+		// 3. Resolve stop and target expressions, then validate price
+		// logic using calculator
+		prices, err := e.resolveLevels(ctx, brk, cmd, opts, currentPrice)
+		if err != nil {
+			fmt.Printf("  ✗ %v\n", err)
+			continue
+		}
+		if err := e.calculator.ValidatePriceLogic(*cmd.Side, prices.Entry, currentPrice); err != nil {
 			fmt.Printf("  ✗ Invalid entry price: %v\n", err)
 			continue
 		}
-		if err := e.calculator.ValidateStopLoss(*cmd.Side, *cmd.EntryPrice, *cmd.StopLoss); err != nil {
+		if err := e.calculator.ValidateStopLoss(*cmd.Side, prices.Entry, prices.Stop); err != nil {
 			fmt.Printf("  ✗ Invalid stop loss: %v\n", err)
 			continue
 		}
 
 		// Warn if entry price is far from current price
-		priceDiff := ((*cmd.EntryPrice - currentPrice) / currentPrice) * 100
+		priceDiff := ((prices.Entry - currentPrice) / currentPrice) * 100
 		if priceDiff > 5 || priceDiff < -5 {
-			fmt.Printf("  ⚠ Entry price %.2f is %.2f%% away from current price %.2f\n",
-				*cmd.EntryPrice, priceDiff, currentPrice)
+			fmt.Printf("  ⚠ Entry price %s is %.2f%% away from current price %s\n",
+				ui.FormatPrice(cmd.Symbol, prices.Entry), priceDiff, ui.FormatPrice(cmd.Symbol, currentPrice))
+		}
+
+		// 4. Calculate position using strategy against the sizing base;
+		// dollar, notional and quantity sizes are expressed as the risk
+		// percent they imply
+		base, capital, err := e.sizingBase(accountName, balance, opts.SizingBase)
+		if err != nil {
+			fmt.Printf("  ✗ %v\n", err)
+			continue
+		}
+		riskPercent, err := request.RiskPercent(capital, prices.Entry, prices.Stop)
+		if err != nil {
+			fmt.Printf("  ✗ Invalid size: %v\n", err)
+			continue
+		}
+		if riskPercent > 100 {
+			fmt.Printf("  ✗ %s would risk %.1f%% of the %s\n", request, riskPercent, base.Label())
+			continue
+		}
+		if request.Model != sizing.ModelRiskPercent && riskPercent > highRiskPercent {
+			fmt.Printf("  ⚠ %s risks %.1f%% of the %s\n", request, riskPercent, base.Label())
 		}
 
-		// 4. Calculate position using strategy
 		plan, err := strat.CalculatePosition(ctx, strategy.PositionParams{
 			Symbol:         cmd.Symbol,
 			Side:           *cmd.Side, // No conversion needed!
-			EntryPrice:     *cmd.EntryPrice,
-			StopLoss:       *cmd.StopLoss,
-			AccountBalance: balance.Available,
-			RiskPercent:    *cmd.RiskPercent,
+			EntryPrice:     prices.Entry,
+			StopLoss:       prices.Stop,
+			AccountBalance: capital,
+			RiskPercent:    riskPercent,
 			MaxLeverage:    125,
 		})
 		if err != nil {
 			fmt.Printf("  ✗ Position calculation failed: %v\n", err)
 			continue
 		}
+		if prices.Target > 0 {
+			plan.TakeProfits = []*types.TakeProfitLevel{{Price: prices.Target}}
+		}
 
-		// 5. Display plan
-		displayPositionPlan(plan, balance.Available)
+		// Shrink the size so fees and buffers fit inside the risk budget,
+		// unless the user fixed the size
+		fees := e.feesFor(accountName, *cmd.Side, prices.Entry, currentPrice)
+		if !request.FixedSize() {
+			sizing.ApplyFees(plan, fees)
+		}
+
+		// 5. Snap the plan to the exchange's tick size, lot step and minimums
+		info, err := e.symbolInfo(ctx, accountName, brk, cmd.Symbol)
+		if errors.Is(err, symbols.ErrUnknownSymbol) {
+			fmt.Printf("  ✗ %v\n", err)
+			continue
+		}
+		if err != nil {
+			fmt.Printf("  ⚠ Symbol rules unavailable, order is not rounded: %v\n", err)
+		} else if info != nil {
+			if err := roundPlan(plan, info); err != nil {
+				fmt.Printf("  ✗ Position rejected by exchange rules: %v\n", err)
+				continue
+			}
+		}
+
+		// 6. Keep the stop ahead of liquidation, lowering leverage if needed
+		marginMode, setMarginMode := e.marginModeFor(ctx, accountName, brk, cmd.Symbol, opts.MarginMode)
+		liquidation, err := e.protectFromLiquidation(ctx, accountName, brk, balance, plan, marginMode)
+		if err != nil {
+			fmt.Printf("  ✗ %v\n", err)
+			continue
+		}
+
+		// 7. Display plan
+		feeBreakdown := settleFees(plan, fees)
+		if capital > 0 {
+			plan.RiskPercent = plan.RiskAmount / capital * 100
+		}
+		displayPositionPlan(plan, planDetails{
+			Strategy:    e.describeStrategy(opts.Strategy),
+			Levels:      prices,
+			Balance:     capital,
+			Base:        base,
+			Sizing:      request,
+			Fees:        feeBreakdown,
+			Liquidation: liquidation,
+			MarginMode:  marginMode,
+		})
 
-		// 6. Set leverage
+		// 8. Enforce portfolio risk limits before touching the exchange
+		if err := e.checkRiskLimits(ctx, accountName, brk, balance, plan); err != nil {
+			fmt.Printf("  ✗ Trade blocked by risk guard:\n")
+			if breach, ok := err.(*risk.BreachError); ok {
+				for _, violation := range breach.Violations {
+					fmt.Printf("    - %s\n", violation)
+				}
+			} else {
+				fmt.Printf("    - %v\n", err)
+			}
+			continue
+		}
+
+		// 9. Set margin mode and leverage
+		if setMarginMode {
+			if err := applyMarginMode(ctx, brk, cmd.Symbol, marginMode); err != nil {
+				fmt.Printf("  ✗ Failed to set %s margin: %v\n", marginMode, err)
+				continue
+			}
+			fmt.Printf("  ✓ Margin mode set to %s\n", marginMode)
+		}
 		leverageSide := "LONG"
 		if plan.Side == strategy.SideShort {
 			leverageSide = "SHORT"
@@ -122,22 +395,107 @@ func (e *Executor) ExecuteOpenPosition(ctx context.Context, cmd *intent.Normaliz
 		}
 		fmt.Printf("  ✓ Leverage set to %dx\n", plan.Leverage)
 
-		// 7. Place order
+		// 10. Place order, noting the position it adds to so verification
+		// can tell a fill from what was already open
+		before, beforeErr := positionSize(ctx, brk, plan.Symbol, plan.Side)
 		orderReq := buildOrderRequest(plan)
 		order, err := brk.PlaceOrder(ctx, orderReq)
+		if errors.Is(err, binance.ErrUnprotectedPosition) {
+			// The entry is live without its stop; keep the plan on record
+			// so the position can be found and protected
+			if order == nil {
+				order = &broker.Order{ID: "unknown"}
+			}
+			fmt.Println(ui.Error(fmt.Sprintf("CRITICAL: order %s may be open WITHOUT a stop loss: %v", order.ID, err)))
+			if err := e.journal.Record(journalEntry(accountName, opts.Strategy, plan, order)); err != nil {
+				fmt.Printf("  ⚠ Failed to record trade in journal: %v\n", err)
+			}
+			fmt.Println(ui.Error(fmt.Sprintf("Protect it now: trading-cli audit --account %s --fix --stop-pct <percent>", accountName)))
+			continue
+		}
 		if err != nil {
 			fmt.Printf("  ✗ Failed to place order: %v\n", err)
 			continue
 		}
 
 		fmt.Printf("  ✓ Order placed: ID %s\n", order.ID)
+
+		// 11. Confirm the protective legs actually attached
+		if beforeErr != nil {
+			fmt.Printf("  ⚠ Could not verify protection: reading the position before placement failed: %v\n", beforeErr)
+		} else {
+			e.verifyProtection(ctx, brk, plan, order, before, opts.RetryProtection)
+		}
+
+		// 12. Journal the plan so reports can express results in R
+		if err := e.journal.Record(journalEntry(accountName, opts.Strategy, plan, order)); err != nil {
+			fmt.Printf("  ⚠ Failed to record trade in journal: %v\n", err)
+		}
 	}
 
 	return nil
 }
 
+// checkRiskLimits evaluates a plan against the account's risk limits.
+// When limits are configured but the portfolio can't be read, the trade
+// is blocked rather than allowed blind.
+func (e *Executor) checkRiskLimits(ctx context.Context, accountName string, brk broker.Broker, balance *broker.Balance, plan *strategy.PositionPlan) error {
+	guard := risk.NewGuard(e.config.RiskLimitsFor(accountName))
+	if !guard.Enabled() {
+		return nil
+	}
+
+	positions, err := brk.GetPositions(ctx, &broker.PositionFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to get positions: %w", err)
+	}
+
+	orders, err := brk.GetOrders(ctx, &broker.OrderFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to get orders: %w", err)
+	}
+
+	proposal := risk.Proposal{
+		Symbol:     plan.Symbol,
+		Side:       plan.Side,
+		Size:       plan.Size,
+		EntryPrice: plan.EntryPrice,
+		Leverage:   plan.Leverage,
+	}
+	if plan.StopLoss != nil {
+		proposal.StopLoss = plan.StopLoss.Price
+	}
+
+	return guard.Check(risk.Portfolio{
+		Equity:    balance.Total + balance.UnrealizedPnL,
+		Positions: positions,
+		Orders:    orders,
+	}, proposal)
+}
+
+// observeDailyLoss records current equity for the session and returns the
+// lockout status, or nil when no daily loss limit is configured
+func (e *Executor) observeDailyLoss(ctx context.Context) (*risk.DailyStatus, error) {
+	if !e.config.DailyLoss.Enabled() {
+		return nil, nil
+	}
+
+	equity := make(map[string]float64)
+	for accountName, brk := range e.brokers {
+		balance, err := brk.GetBalance(ctx)
+		if err != nil {
+			continue // Account will report its own balance error later
+		}
+		equity[accountName] = balance.Total + balance.UnrealizedPnL
+	}
+
+	return e.daily.Observe(equity)
+}
+
 // ExecuteGetBalance retrieves balance for all accounts
 func (e *Executor) ExecuteGetBalance(ctx context.Context) error {
+	equity := make(map[string]float64)
+
 	for accountName, brk := range e.brokers {
 		fmt.Println(ui.Account(accountName))
 
@@ -148,6 +506,18 @@ func (e *Executor) ExecuteGetBalance(ctx context.Context) error {
 		}
 
 		fmt.Println(ui.FormatBalance(balance))
+		equity[accountName] = balance.Total + balance.UnrealizedPnL
+		e.recordSnapshot(accountName, balance)
+	}
+
+	// Balance checks also seed start-of-day equity for the loss lockout
+	if e.config.DailyLoss.Enabled() {
+		status, err := e.daily.Observe(equity)
+		if err != nil {
+			fmt.Println(ui.Warning(fmt.Sprintf("Failed to update daily session: %v", err)))
+			return nil
+		}
+		fmt.Println(ui.FormatDailyStatus(status))
 	}
 
 	return nil
@@ -162,6 +532,7 @@ func (e *Executor) ExecuteGetPositions(ctx context.Context, symbol string) error
 
 	for accountName, brk := range e.brokers {
 		fmt.Println(ui.Account(accountName))
+		e.loadPrecision(ctx, accountName, brk)
 
 		positions, err := brk.GetPositions(ctx, filter)
 		if err != nil {
@@ -177,7 +548,7 @@ func (e *Executor) ExecuteGetPositions(ctx context.Context, symbol string) error
 		}
 
 		// Use table formatter with orders for TP/SL display
-		fmt.Println(ui.FormatPositionsTable(positions, orders))
+		fmt.Println(ui.FormatPositionsTable(positions, orders, e.positionDetails(ctx, accountName, brk, positions)))
 	}
 
 	return nil
@@ -192,6 +563,7 @@ func (e *Executor) ExecuteGetOrders(ctx context.Context, symbol string, verbose
 
 	for accountName, brk := range e.brokers {
 		fmt.Println(ui.Account(accountName))
+		e.loadPrecision(ctx, accountName, brk)
 
 		orders, err := brk.GetOrders(ctx, filter)
 		if err != nil {
@@ -213,6 +585,73 @@ func (e *Executor) ExecuteGetOrders(ctx context.Context, symbol string, verbose
 	return nil
 }
 
+// ExecuteRiskReport shows what every account loses if all stops are hit
+func (e *Executor) ExecuteRiskReport(ctx context.Context, asJSON bool) error {
+	accounts := make([]risk.AccountRisk, 0, len(e.brokers))
+
+	for accountName, brk := range e.brokers {
+		e.loadPrecision(ctx, accountName, brk)
+		accountRisk, err := e.accountRisk(ctx, accountName, brk)
+		if err != nil {
+			accountRisk = risk.AccountRisk{Account: accountName, Error: err.Error()}
+		}
+		accounts = append(accounts, accountRisk)
+	}
+
+	report := risk.NewReport(accounts)
+
+	if asJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	for _, accountRisk := range report.Accounts {
+		fmt.Println(ui.Account(accountRisk.Account))
+		if accountRisk.Error != "" {
+			fmt.Println(ui.Error(accountRisk.Error))
+			continue
+		}
+		fmt.Println(ui.FormatRiskReport(accountRisk))
+	}
+
+	fmt.Println(ui.FormatRiskTotal(report.Total))
+
+	return nil
+}
+
+// accountRisk gathers balance, positions and stops for one account
+func (e *Executor) accountRisk(ctx context.Context, accountName string, brk broker.Broker) (risk.AccountRisk, error) {
+	balance, err := brk.GetBalance(ctx)
+	if err != nil {
+		return risk.AccountRisk{}, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	positions, err := brk.GetPositions(ctx, &broker.PositionFilter{})
+	if err != nil {
+		return risk.AccountRisk{}, fmt.Errorf("failed to get positions: %w", err)
+	}
+
+	orders, err := brk.GetOrders(ctx, &broker.OrderFilter{})
+	if err != nil {
+		return risk.AccountRisk{}, fmt.Errorf("failed to get orders: %w", err)
+	}
+
+	initialRisk := func(symbol string) float64 {
+		entry, err := e.journal.Latest(accountName, symbol)
+		if err != nil || entry == nil {
+			return 0
+		}
+		return entry.RiskAmount
+	}
+
+	equity := balance.Total + balance.UnrealizedPnL
+	return risk.BuildAccountRisk(accountName, equity, positions, orders, initialRisk), nil
+}
+
 // ExecuteCancelOrders cancels orders for all accounts
 func (e *Executor) ExecuteCancelOrders(ctx context.Context, symbol string) error {
 	for accountName, brk := range e.brokers {
@@ -279,7 +718,7 @@ func (e *Executor) ExecuteClosePosition(ctx context.Context, symbol string, perc
 
 			// Close each position
 			for _, pos := range positions {
-				if err := e.closePosition(ctx, brk, pos, percentage); err != nil {
+				if err := e.closePosition(ctx, accountName, brk, pos, percentage); err != nil {
 					fmt.Printf("  ✗ Failed to close %s: %v\n", pos.Symbol, err)
 				}
 			}
@@ -296,7 +735,7 @@ func (e *Executor) ExecuteClosePosition(ctx context.Context, symbol string, perc
 			continue
 		}
 
-		if err := e.closePosition(ctx, brk, position, percentage); err != nil {
+		if err := e.closePosition(ctx, accountName, brk, position, percentage); err != nil {
 			fmt.Printf("  ✗ Failed to close position: %v\n", err)
 		}
 	}
@@ -305,39 +744,35 @@ func (e *Executor) ExecuteClosePosition(ctx context.Context, symbol string, perc
 }
 
 // closePosition closes a single position
-func (e *Executor) closePosition(ctx context.Context, brk broker.Broker, pos *broker.Position, percentage float64) error {
+func (e *Executor) closePosition(ctx context.Context, accountName string, brk broker.Broker, pos *broker.Position, percentage float64) error {
 	// Calculate size to close
 	size := pos.Size
 	if percentage > 0 && percentage < 100 {
 		size = pos.Size * (percentage / 100)
 	}
 
-	// Determine close side (opposite of position side)
-	closeSide := broker.SideLong
-	if pos.Side == broker.SideLong {
-		closeSide = broker.SideShort
+	// Partial closes must land on the lot step
+	info, err := e.symbolInfo(ctx, accountName, brk, pos.Symbol)
+	if err != nil {
+		info = nil
 	}
-
-	// Place market order to close
-	orderReq := &broker.OrderRequest{
-		Symbol:     pos.Symbol,
-		Side:       closeSide,
-		Type:       broker.OrderTypeMarket,
-		Size:       size,
-		ReduceOnly: true,
+	size, err = roundCloseSize(info, size, pos.Size)
+	if err != nil {
+		return err
 	}
 
-	order, err := brk.PlaceOrder(ctx, orderReq)
+	// Place market order to close
+	order, err := brk.PlaceOrder(ctx, buildCloseRequest(pos, size))
 	if err != nil {
 		return err
 	}
 
 	if percentage > 0 && percentage < 100 {
-		fmt.Printf("  ✓ Closed %.0f%% of %s position (%.4f) | Order: %s\n",
-			percentage, pos.Symbol, size, order.ID)
+		fmt.Printf("  ✓ Closed %.0f%% of %s position (%s) | Order: %s\n",
+			percentage, pos.Symbol, ui.FormatSize(pos.Symbol, size), order.ID)
 	} else {
-		fmt.Printf("  ✓ Closed %s position (%.4f) | Order: %s\n",
-			pos.Symbol, size, order.ID)
+		fmt.Printf("  ✓ Closed %s position (%s) | Order: %s\n",
+			pos.Symbol, ui.FormatSize(pos.Symbol, size), order.ID)
 	}
 
 	return nil
@@ -348,6 +783,11 @@ func (e *Executor) ExecuteTrailingStop(ctx context.Context, symbol string, trigg
 	for accountName, brk := range e.brokers {
 		fmt.Printf("\n💼 Account: %s\n", accountName)
 
+		if !e.capabilities[accountName].TrailingStop {
+			fmt.Printf("  ✗ Trailing stops are not supported by this broker\n")
+			continue
+		}
+
 		// Get position
 		position, err := brk.GetPosition(ctx, symbol)
 		if err != nil {
@@ -366,6 +806,11 @@ func (e *Executor) ExecuteTrailingStop(ctx context.Context, symbol string, trigg
 			trailSide = broker.SideLong // Close short
 		}
 
+		activationPrice := triggerPrice
+		if info, err := e.symbolInfo(ctx, accountName, brk, symbol); err == nil && info != nil {
+			activationPrice = info.RoundPrice(triggerPrice)
+		}
+
 		// Place trailing stop order
 		orderReq := &broker.OrderRequest{
 			Symbol:     symbol,
@@ -374,7 +819,7 @@ func (e *Executor) ExecuteTrailingStop(ctx context.Context, symbol string, trigg
 			Size:       position.Size,
 			ReduceOnly: true,
 			Trailing: &broker.TrailingConfig{
-				ActivationPrice: triggerPrice,
+				ActivationPrice: activationPrice,
 				CallbackRate:    callbackRate / 100, // Convert percentage to decimal
 			},
 		}
@@ -386,7 +831,7 @@ func (e *Executor) ExecuteTrailingStop(ctx context.Context, symbol string, trigg
 		}
 
 		fmt.Printf("  ✓ Trailing stop set for %s\n", symbol)
-		fmt.Printf("    Activation: %.2f\n", triggerPrice)
+		fmt.Printf("    Activation: %s\n", formatPrice(symbol, activationPrice))
 		fmt.Printf("    Callback:   %.2f%%\n", callbackRate)
 		fmt.Printf("    Order ID:   %s\n", order.ID)
 	}
@@ -423,13 +868,19 @@ func (e *Executor) ExecuteBreakEven(ctx context.Context, symbol string) error {
 			stopSide = broker.SideLong
 		}
 
+		// Average entry prices rarely sit on a tick
+		stopPrice := position.EntryPrice
+		if info, err := e.symbolInfo(ctx, accountName, brk, symbol); err == nil && info != nil {
+			stopPrice = info.RoundPrice(position.EntryPrice)
+		}
+
 		// Place new stop loss at entry price
 		orderReq := &broker.OrderRequest{
 			Symbol:     symbol,
 			Side:       stopSide,
 			Type:       broker.OrderTypeStop,
 			Size:       position.Size,
-			StopPrice:  position.EntryPrice,
+			StopPrice:  stopPrice,
 			ReduceOnly: true,
 		}
 
@@ -440,7 +891,7 @@ func (e *Executor) ExecuteBreakEven(ctx context.Context, symbol string) error {
 		}
 
 		fmt.Printf("  ✓ Break even set for %s\n", symbol)
-		fmt.Printf("    Entry price: %.2f\n", position.EntryPrice)
+		fmt.Printf("    Entry price: %s\n", formatPrice(symbol, stopPrice))
 		fmt.Printf("    Order ID:    %s\n", order.ID)
 	}
 
@@ -450,22 +901,92 @@ func (e *Executor) ExecuteBreakEven(ctx context.Context, symbol string) error {
 // Helper functions
 // Note: Type conversion functions removed - all modules now use trading-common-types!
 
-func displayPositionPlan(plan *strategy.PositionPlan, availableBalance float64) {
+func displayPositionPlan(plan *strategy.PositionPlan, details planDetails) {
+	prices := details.Levels
+	if prices == nil {
+		prices = &tradeLevels{}
+	}
+
 	fmt.Printf("\n  Position Plan\n")
-	fmt.Printf("  Balance:       $%.2f\n", availableBalance)
+	if details.Strategy != "" {
+		fmt.Printf("  Strategy:      %s\n", details.Strategy)
+	}
+	fmt.Printf("  Balance:       $%.2f (%s)\n", details.Balance, details.Base.Label())
+	if details.Sizing.Model != sizing.ModelRiskPercent {
+		fmt.Printf("  Sizing:        %s\n", details.Sizing)
+	}
 	fmt.Printf("  Risk Amount:   $%.2f (%.1f%%)\n", plan.RiskAmount, plan.RiskPercent)
-	fmt.Printf("  Size:          %.4f\n", plan.Size)
-	fmt.Printf("  Entry:         %.2f\n", plan.EntryPrice)
+	if fees := details.Fees; fees != nil {
+		fmt.Printf("    incl. fees:  $%.2f (entry $%.2f + stop exit $%.2f + slippage $%.2f + funding $%.2f)\n",
+			fees.Total, fees.EntryFee, fees.ExitFee, fees.Slippage, fees.Funding)
+	}
+	fmt.Printf("  Size:          %s\n", ui.FormatSize(plan.Symbol, plan.Size))
+	fmt.Printf("  Entry:         %s%s\n", formatPrice(plan.Symbol, plan.EntryPrice), levelNote(prices.EntryNote))
 	if plan.StopLoss != nil {
-		fmt.Printf("  Stop Loss:     %.2f\n", plan.StopLoss.Price)
+		fmt.Printf("  Stop Loss:     %s%s\n", formatPrice(plan.Symbol, plan.StopLoss.Price), levelNote(prices.StopNote))
 	}
-	if len(plan.TakeProfits) > 0 {
-		fmt.Printf("  Take Profit:   %.2f\n", plan.TakeProfits[0].Price)
+	for i, tp := range plan.TakeProfits {
+		label, note := "Take Profit:", ""
+		if len(plan.TakeProfits) > 1 {
+			label = fmt.Sprintf("TP%d:", i+1)
+		}
+		if i == 0 {
+			note = levelNote(prices.TargetNote)
+		}
+		fmt.Printf("  %-15s%s%s\n", label, formatPrice(plan.Symbol, tp.Price), note)
+		if i < len(details.Rewards) && plan.RiskAmount > 0 {
+			fmt.Printf("    reward:      $%.2f (%.2fR)\n", details.Rewards[i], details.Rewards[i]/plan.RiskAmount)
+		}
+	}
+	if prices.ATR > 0 {
+		fmt.Printf("  ATR:           %s (%d × %s)\n", formatPrice(plan.Symbol, prices.ATR), prices.ATRPeriod, prices.ATRInterval)
 	}
 	fmt.Printf("  Leverage:      %dx\n", plan.Leverage)
+	if details.MarginMode != "" {
+		fmt.Printf("  Margin:        %s\n", details.MarginMode)
+	}
+	fmt.Printf("  Liquidation:   %s isolated / %s cross\n",
+		formatLiquidation(plan.Symbol, details.Liquidation.Isolated, true), formatLiquidation(plan.Symbol, details.Liquidation.Cross, true))
 	fmt.Printf("  Notional:      $%.2f\n\n", plan.NotionalValue)
 }
 
+func journalEntry(accountName, strategyName string, plan *strategy.PositionPlan, order *broker.Order) journal.Entry {
+	entry := journal.Entry{
+		Account:    accountName,
+		Symbol:     plan.Symbol,
+		Side:       plan.Side,
+		Size:       plan.Size,
+		EntryPrice: plan.EntryPrice,
+		RiskAmount: plan.RiskAmount,
+		Strategy:   strategyName,
+		OrderID:    order.ID,
+	}
+	if plan.StopLoss != nil {
+		entry.StopLoss = plan.StopLoss.Price
+	}
+	if len(plan.TakeProfits) > 0 {
+		entry.TakeProfit = plan.TakeProfits[0].Price
+	}
+	return entry
+}
+
+// buildCloseRequest builds a reduce-only market order closing size of a position
+func buildCloseRequest(pos *broker.Position, size float64) *broker.OrderRequest {
+	// Determine close side (opposite of position side)
+	closeSide := broker.SideLong
+	if pos.Side == broker.SideLong {
+		closeSide = broker.SideShort
+	}
+
+	return &broker.OrderRequest{
+		Symbol:     pos.Symbol,
+		Side:       closeSide,
+		Type:       broker.OrderTypeMarket,
+		Size:       size,
+		ReduceOnly: true,
+	}
+}
+
 func buildOrderRequest(plan *strategy.PositionPlan) *broker.OrderRequest {
 	req := &broker.OrderRequest{
 		Symbol: plan.Symbol,
@@ -0,0 +1,214 @@
+package executor
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/brokers"
+	"github.com/agatticelli/trading-cli/internal/history"
+	"github.com/agatticelli/trading-cli/internal/journal"
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-cli/internal/ui"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// Export formats for trade history and stats
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// HistoryOptions selects the trades ExecuteHistory shows
+type HistoryOptions struct {
+	Symbol string // Empty for every symbol
+	Period history.Period
+	Format string // "" for tables, FormatJSON or FormatCSV
+}
+
+// accountHistory is one account's reconstructed trades
+type accountHistory struct {
+	Account string
+	history.Result
+	Err error
+}
+
+// ExecuteHistory shows closed round trips with fees, realized PnL and R
+func (e *Executor) ExecuteHistory(ctx context.Context, opts HistoryOptions) error {
+	accounts := e.collectHistory(ctx, opts.Symbol, opts.Period)
+
+	if opts.Format != "" {
+		trades := []history.Trade{}
+		for _, account := range accounts {
+			if account.Err != nil {
+				return fmt.Errorf("account %s: %w", account.Account, account.Err)
+			}
+			trades = append(trades, account.Trades...)
+		}
+		return exportTrades(trades, opts.Format)
+	}
+
+	fmt.Println(ui.MutedStyle.Render("Period: " + opts.Period.String()))
+	for _, account := range accounts {
+		fmt.Println(ui.Account(account.Account))
+		if account.Err != nil {
+			fmt.Println(ui.Error(account.Err.Error()))
+			continue
+		}
+
+		fmt.Print(ui.FormatTradeHistory(account.Trades))
+		if account.Open > 0 {
+			fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  %d position(s) still open are not listed", account.Open)))
+		}
+		if account.Unmatched > 0 {
+			fmt.Println(ui.Warning(fmt.Sprintf("%d fill(s) belong to positions opened before the period; widen --since to include them", account.Unmatched)))
+		}
+	}
+
+	return nil
+}
+
+// collectHistory fetches fills for every account, pairs them into round
+// trips and attaches R from the journal. Accounts are in name order.
+func (e *Executor) collectHistory(ctx context.Context, symbol string, period history.Period) []accountHistory {
+	entries, err := e.journal.Entries()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, ui.Warning(fmt.Sprintf("Failed to read the trade journal, R is unavailable: %v", err)))
+	}
+
+	names := make([]string, 0, len(e.brokers))
+	for name := range e.brokers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	accounts := make([]accountHistory, 0, len(names))
+	for _, accountName := range names {
+		account := accountHistory{Account: accountName}
+
+		provider, ok := e.brokers[accountName].(brokers.FillProvider)
+		if !ok {
+			account.Err = fmt.Errorf("trade history is not supported by %s", e.brokerNames[accountName])
+			accounts = append(accounts, account)
+			continue
+		}
+
+		e.loadPrecision(ctx, accountName, e.brokers[accountName])
+
+		// Fills run to now so the positions held now tell what was
+		// already open when the period began
+		to := time.Now()
+		if period.To.After(to) {
+			to = period.To
+		}
+		fills, err := provider.GetFills(ctx, symbol, period.From, to)
+		if err != nil {
+			account.Err = fmt.Errorf("failed to get fills: %w", err)
+			accounts = append(accounts, account)
+			continue
+		}
+		positions, err := e.brokers[accountName].GetPositions(ctx, &broker.PositionFilter{})
+		if err != nil {
+			account.Err = fmt.Errorf("failed to get positions: %w", err)
+			accounts = append(accounts, account)
+			continue
+		}
+
+		var inPeriod, later []market.Fill
+		for _, fill := range fills {
+			if fill.Time.After(period.To) {
+				later = append(later, fill)
+			} else {
+				inPeriod = append(inPeriod, fill)
+			}
+		}
+
+		account.Result = history.Reconstruct(inPeriod, history.Held(positions, later))
+		for i := range account.Trades {
+			trade := &account.Trades[i]
+			trade.Account = accountName
+			attachRisk(trade, journal.Match(entries, accountName, trade.Symbol, trade.OrderID, trade.Side, trade.OpenedAt))
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts
+}
+
+// attachRisk sets a trade's risk and R from its journal entry, scaling the
+// planned risk to the size that actually filled
+func attachRisk(trade *history.Trade, entry *journal.Entry) {
+	if entry == nil || entry.RiskAmount <= 0 {
+		return
+	}
+
+	trade.Risk = entry.RiskAmount
+	if entry.Size > 0 {
+		trade.Risk = entry.RiskAmount / entry.Size * trade.Size
+	}
+	r := trade.NetPnL / trade.Risk
+	trade.R = &r
+}
+
+// exportTrades writes trades to stdout as JSON or CSV
+func exportTrades(trades []history.Trade, format string) error {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(trades, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode trades: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+
+	case FormatCSV:
+		w := csv.NewWriter(os.Stdout)
+		w.Write([]string{"account", "symbol", "side", "opened_at", "closed_at", "entry_price", "exit_price",
+			"size", "fees", "other_fees", "pnl", "net_pnl", "risk", "r", "fills", "order_id"})
+		for _, t := range trades {
+			r := ""
+			if t.R != nil {
+				r = formatCSVFloat(*t.R)
+			}
+			w.Write([]string{
+				t.Account, t.Symbol, string(t.Side),
+				t.OpenedAt.UTC().Format(time.RFC3339), t.ClosedAt.UTC().Format(time.RFC3339),
+				formatCSVFloat(t.Entry), formatCSVFloat(t.Exit), formatCSVFloat(t.Size),
+				formatCSVFloat(t.Fees), formatOtherFees(t.OtherFees), formatCSVFloat(t.PnL), formatCSVFloat(t.NetPnL),
+				formatCSVFloat(t.Risk), r, strconv.Itoa(t.Fills), t.OrderID,
+			})
+		}
+		w.Flush()
+		return w.Error()
+
+	default:
+		return fmt.Errorf("unknown export format: %s (use json or csv)", format)
+	}
+}
+
+// formatOtherFees renders fees paid outside the quote asset as
+// "BNB:0.0012", by asset
+func formatOtherFees(fees map[string]float64) string {
+	assets := make([]string, 0, len(fees))
+	for asset := range fees {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+
+	parts := make([]string, len(assets))
+	for i, asset := range assets {
+		parts[i] = asset + ":" + formatCSVFloat(fees[asset])
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatCSVFloat renders a number without exponent or trailing zeros
+func formatCSVFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
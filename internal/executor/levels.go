@@ -0,0 +1,144 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/agatticelli/intent-go"
+	"github.com/agatticelli/trading-cli/internal/levels"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// Defaults for atr: stops and targets when neither flags nor config set them
+const (
+	defaultATRPeriod   = 14
+	defaultATRInterval = "1h"
+)
+
+// tradeLevels are a trade's prices resolved for one account, with the
+// expressions they came from for the plan
+type tradeLevels struct {
+	Entry       float64
+	Stop        float64
+	Target      float64 // Zero when the strategy places the take profit
+	ATR         float64 // Zero unless an expression used ATR
+	ATRPeriod   int
+	ATRInterval string
+
+	EntryNote  string // e.g. "mark -0.3%"; empty for absolute prices
+	StopNote   string
+	TargetNote string
+}
+
+// resolveLevels turns the command's prices and any entry, stop or target
+// expressions into absolute prices for one account. The entry resolves
+// against the account's current price, stops and targets against the
+// entry, and ATR comes from the account's own candles.
+func (e *Executor) resolveLevels(ctx context.Context, brk broker.Broker, cmd *intent.NormalizedCommand, opts OpenOptions, currentPrice float64) (*tradeLevels, error) {
+	entry := opts.Entry
+	if entry == nil {
+		price := levels.Price(*cmd.EntryPrice)
+		entry = &price
+	}
+	stop := opts.StopLoss
+	if stop == nil {
+		price := levels.Price(*cmd.StopLoss)
+		stop = &price
+	}
+	target := opts.TakeProfit
+	if target == nil && cmd.TakeProfit != nil && *cmd.TakeProfit > 0 {
+		price := levels.Price(*cmd.TakeProfit)
+		target = &price
+	}
+
+	resolved := &tradeLevels{}
+	var err error
+	resolved.Entry, err = entry.Entry(currentPrice)
+	if err != nil {
+		return nil, fmt.Errorf("entry %s: %w", entry, err)
+	}
+	resolved.EntryNote = entryNote(*entry)
+
+	if stop.NeedsATR() || (target != nil && target.NeedsATR()) {
+		resolved.ATRPeriod, resolved.ATRInterval = e.atrSettings(opts.ATRPeriod, opts.ATRInterval)
+		atr, err := e.fetchATR(ctx, brk, cmd.Symbol, resolved.ATRInterval, resolved.ATRPeriod)
+		if err != nil {
+			return nil, fmt.Errorf("ATR(%d, %s) unavailable: %w", resolved.ATRPeriod, resolved.ATRInterval, err)
+		}
+		resolved.ATR = atr
+	}
+
+	resolved.Stop, err = stop.Stop(*cmd.Side, resolved.Entry, resolved.ATR)
+	if err != nil {
+		return nil, fmt.Errorf("stop loss %s: %w", stop, err)
+	}
+	resolved.StopNote = exitNote(*stop, resolved.Entry)
+
+	if target != nil {
+		resolved.Target, err = target.Target(*cmd.Side, resolved.Entry, resolved.ATR)
+		if err != nil {
+			return nil, fmt.Errorf("take profit %s: %w", target, err)
+		}
+		if (*cmd.Side == broker.SideLong && resolved.Target <= resolved.Entry) ||
+			(*cmd.Side == broker.SideShort && resolved.Target >= resolved.Entry) {
+			return nil, fmt.Errorf("take profit %s is on the losing side of entry %s",
+				formatPrice(cmd.Symbol, resolved.Target), formatPrice(cmd.Symbol, resolved.Entry))
+		}
+		resolved.TargetNote = exitNote(*target, resolved.Entry)
+	}
+
+	return resolved, nil
+}
+
+// atrSettings returns the ATR period and interval: flags, then config,
+// then defaults
+func (e *Executor) atrSettings(period int, interval string) (int, string) {
+	if period == 0 {
+		period = e.config.ATR.Period
+	}
+	if period == 0 {
+		period = defaultATRPeriod
+	}
+	if interval == "" {
+		interval = e.config.ATR.Interval
+	}
+	if interval == "" {
+		interval = defaultATRInterval
+	}
+	return period, interval
+}
+
+// entryNote describes a relative entry for the plan
+func entryNote(expr levels.Expr) string {
+	switch expr.Kind {
+	case levels.KindMark:
+		return "mark"
+	case levels.KindPercent:
+		return fmt.Sprintf("mark %+g%%", expr.Value)
+	default:
+		return ""
+	}
+}
+
+// exitNote describes a relative stop or target for the plan
+func exitNote(expr levels.Expr, entry float64) string {
+	switch {
+	case expr.Kind == levels.KindPercent:
+		return fmt.Sprintf("%g%% from entry", math.Abs(expr.Value))
+	case expr.NeedsATR():
+		return fmt.Sprintf("%g ATR from entry", expr.Value)
+	case expr.Distance(entry):
+		return fmt.Sprintf("%g from entry", expr.Value)
+	default:
+		return ""
+	}
+}
+
+// levelNote formats a plan note as a suffix
+func levelNote(note string) string {
+	if note == "" {
+		return ""
+	}
+	return " (" + note + ")"
+}
@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/agatticelli/strategy-go"
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-cli/internal/risk"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// maintenanceRate returns an account's maintenance margin rate as a fraction
+func (e *Executor) maintenanceRate(accountName string) float64 {
+	if percent := e.config.RiskLimitsFor(accountName).MaintenanceMarginPercent; percent > 0 {
+		return percent / 100
+	}
+	return risk.DefaultMaintenanceRate
+}
+
+// protectFromLiquidation estimates where a plan would be liquidated and
+// lowers its leverage until the isolated liquidation lies beyond the stop.
+// It fails when no leverage works, the lower leverage needs more margin
+// than the account has available, or a cross stop lies past what the
+// account's equity can absorb. An unknown margin mode is checked as isolated.
+func (e *Executor) protectFromLiquidation(ctx context.Context, accountName string, brk broker.Broker, balance *broker.Balance, plan *strategy.PositionPlan, mode market.MarginMode) (risk.Liquidation, error) {
+	rate := e.maintenanceRate(accountName)
+
+	if mode != market.MarginCross {
+		safe, isolated, err := safeLeverage(plan, rate)
+		if err != nil {
+			return risk.Liquidation{}, err
+		}
+
+		if margin := plan.NotionalValue / float64(safe); safe < plan.Leverage && margin > balance.Available {
+			return risk.Liquidation{}, fmt.Errorf("stop loss %s is past the liquidation estimate %s at %dx; %dx would need $%.2f margin, $%.2f available",
+				formatPrice(plan.Symbol, plan.StopLoss.Price), formatPrice(plan.Symbol, isolated), plan.Leverage, safe, margin, balance.Available)
+		}
+		lowerLeverage(plan, safe, isolated)
+	}
+
+	// Cross positions are backed by equity left after other positions' maintenance
+	collateral := balance.Total + balance.UnrealizedPnL
+	if positions, err := brk.GetPositions(ctx, &broker.PositionFilter{}); err == nil {
+		others := make([]*broker.Position, 0, len(positions))
+		for _, pos := range positions {
+			if pos.Symbol != plan.Symbol {
+				others = append(others, pos)
+			}
+		}
+		collateral = risk.CrossCollateral(collateral, others, rate)
+	}
+
+	liquidation := risk.Liquidation{
+		Isolated: risk.IsolatedLiquidationPrice(plan.Side, plan.EntryPrice, plan.Leverage, rate),
+		Cross:    risk.LiquidationPrice(plan.Side, plan.EntryPrice, plan.Size, collateral, rate),
+	}
+
+	// Leverage doesn't move a cross liquidation; only equity does
+	if plan.StopLoss != nil && mode == market.MarginCross && risk.StopBeyondLiquidation(plan.Side, plan.StopLoss.Price, liquidation.Cross) {
+		return risk.Liquidation{}, fmt.Errorf("stop loss %s is past the cross liquidation estimate %s; the account can't absorb this loss",
+			formatPrice(plan.Symbol, plan.StopLoss.Price), formatPrice(plan.Symbol, liquidation.Cross))
+	}
+
+	return liquidation, nil
+}
+
+// safeLeverage returns the highest leverage up to the plan's that keeps
+// its isolated liquidation beyond the stop, and the liquidation estimate
+// at the plan's leverage. It fails when no leverage works.
+func safeLeverage(plan *strategy.PositionPlan, rate float64) (int, float64, error) {
+	isolated := risk.IsolatedLiquidationPrice(plan.Side, plan.EntryPrice, plan.Leverage, rate)
+	if plan.StopLoss == nil || !risk.StopBeyondLiquidation(plan.Side, plan.StopLoss.Price, isolated) {
+		return plan.Leverage, isolated, nil
+	}
+
+	safe := risk.MaxSafeLeverage(plan.Side, plan.EntryPrice, plan.StopLoss.Price, rate)
+	if safe < 1 {
+		return 0, isolated, fmt.Errorf("stop loss %s is past the liquidation price at any leverage",
+			formatPrice(plan.Symbol, plan.StopLoss.Price))
+	}
+	return safe, isolated, nil
+}
+
+// lowerLeverage applies a leverage from safeLeverage, warning when it is
+// below the plan's
+func lowerLeverage(plan *strategy.PositionPlan, safe int, isolated float64) {
+	if safe >= plan.Leverage {
+		return
+	}
+	fmt.Printf("  ⚠ Leverage lowered from %dx to %dx: stop %s was past the liquidation estimate %s\n",
+		plan.Leverage, safe, formatPrice(plan.Symbol, plan.StopLoss.Price), formatPrice(plan.Symbol, isolated))
+	plan.Leverage = safe
+}
+
+// formatLiquidation renders a liquidation price, where zero means none.
+// Estimates are marked "~"; prices reported by the exchange are not.
+func formatLiquidation(symbol string, price float64, estimate bool) string {
+	if price <= 0 {
+		return "none"
+	}
+	if estimate {
+		return "~" + formatPrice(symbol, price)
+	}
+	return formatPrice(symbol, price)
+}
@@ -0,0 +1,176 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/agatticelli/trading-cli/internal/brokers"
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-cli/internal/risk"
+	"github.com/agatticelli/trading-cli/internal/ui"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// marginModeFor picks the margin mode for a trade: the requested mode,
+// else the account default, else whatever the exchange currently has.
+// explicit reports whether the mode must be set before ordering: only when
+// the exchange has another mode, or its mode can't be read, since not
+// every exchange accepts a switch to the mode already set.
+func (e *Executor) marginModeFor(ctx context.Context, accountName string, brk broker.Broker, symbol string, requested market.MarginMode) (mode market.MarginMode, explicit bool) {
+	var current market.MarginMode
+	if controller, ok := brk.(brokers.MarginController); ok {
+		if mode, err := controller.GetMarginMode(ctx, symbol); err == nil {
+			current = mode
+		}
+	}
+
+	mode = requested
+	if mode == "" {
+		if account, err := e.config.GetAccountByName(accountName); err == nil && account.MarginMode != "" {
+			if parsed, err := market.ParseMarginMode(account.MarginMode); err == nil {
+				mode = parsed
+			}
+		}
+	}
+	if mode == "" {
+		return current, false
+	}
+
+	return mode, mode != current
+}
+
+// applyMarginMode switches the symbol's margin mode on the exchange
+func applyMarginMode(ctx context.Context, brk broker.Broker, symbol string, mode market.MarginMode) error {
+	controller, ok := brk.(brokers.MarginController)
+	if !ok {
+		return fmt.Errorf("margin mode selection is not supported by this broker")
+	}
+	return controller.SetMarginMode(ctx, symbol, mode)
+}
+
+// positionRisks returns what the exchange reports about open positions'
+// liquidation, by symbol, or nil when the broker doesn't report it
+func positionRisks(ctx context.Context, brk broker.Broker, symbol string) map[string]market.PositionRisk {
+	provider, ok := brk.(brokers.PositionRiskProvider)
+	if !ok {
+		return nil
+	}
+	risks, err := provider.GetPositionRisks(ctx, symbol)
+	if err != nil {
+		return nil
+	}
+
+	bySymbol := make(map[string]market.PositionRisk, len(risks))
+	for _, r := range risks {
+		bySymbol[r.Symbol] = r
+	}
+	return bySymbol
+}
+
+// positionDetails reports liquidation and margin mode for open positions:
+// the exchange's own figures where it reports them, else estimates. Cross
+// positions are estimated against the account's equity.
+func (e *Executor) positionDetails(ctx context.Context, accountName string, brk broker.Broker, positions []*broker.Position) map[string]ui.PositionDetails {
+	rate := e.maintenanceRate(accountName)
+	controller, hasModes := brk.(brokers.MarginController)
+	reported := positionRisks(ctx, brk, "")
+
+	var balance *broker.Balance
+	details := make(map[string]ui.PositionDetails, len(positions))
+
+	for _, pos := range positions {
+		detail := ui.PositionDetails{
+			Liquidation: risk.IsolatedLiquidationPrice(pos.Side, pos.EntryPrice, pos.Leverage, rate),
+		}
+
+		if r, ok := reported[pos.Symbol]; ok {
+			detail.MarginMode = string(r.MarginMode)
+			if r.Liquidation > 0 {
+				detail.Liquidation = r.Liquidation
+				detail.Reported = true
+				details[pos.Symbol] = detail
+				continue
+			}
+		}
+
+		if hasModes && detail.MarginMode == "" {
+			if mode, err := controller.GetMarginMode(ctx, pos.Symbol); err == nil {
+				detail.MarginMode = string(mode)
+			}
+		}
+
+		if detail.MarginMode == string(market.MarginCross) {
+			if balance == nil {
+				balance, _ = brk.GetBalance(ctx)
+			}
+			if balance != nil {
+				others := make([]*broker.Position, 0, len(positions)-1)
+				for _, other := range positions {
+					if other != pos {
+						others = append(others, other)
+					}
+				}
+				collateral := risk.CrossCollateral(balance.Total+balance.UnrealizedPnL, others, rate)
+				detail.Liquidation = risk.LiquidationPrice(pos.Side, pos.EntryPrice, pos.Size, collateral, rate)
+			}
+		}
+
+		details[pos.Symbol] = detail
+	}
+
+	return details
+}
+
+// ExecuteAdjustMargin adds (amount > 0) or removes (amount < 0) margin on
+// isolated positions
+func (e *Executor) ExecuteAdjustMargin(ctx context.Context, symbol string, amount float64) error {
+	for accountName, brk := range e.brokers {
+		fmt.Printf("\n💼 Account: %s\n", accountName)
+
+		controller, ok := brk.(brokers.MarginController)
+		if !ok {
+			fmt.Printf("  ✗ Margin adjustment is not supported by this broker\n")
+			continue
+		}
+
+		position, err := brk.GetPosition(ctx, symbol)
+		if err != nil {
+			fmt.Printf("  ✗ Failed to get position: %v\n", err)
+			continue
+		}
+
+		if position == nil {
+			fmt.Printf("  No position found for %s\n", symbol)
+			continue
+		}
+
+		if mode, err := controller.GetMarginMode(ctx, symbol); err == nil && mode == market.MarginCross {
+			fmt.Printf("  ✗ %s uses cross margin; only isolated positions can be adjusted\n", symbol)
+			continue
+		}
+
+		if err := controller.AdjustIsolatedMargin(ctx, symbol, position.Side, amount); err != nil {
+			fmt.Printf("  ✗ Failed to adjust margin: %v\n", err)
+			continue
+		}
+
+		action := "Added"
+		if amount < 0 {
+			action = "Removed"
+		}
+		fmt.Printf("  ✓ %s $%.2f margin on %s\n", action, math.Abs(amount), symbol)
+
+		// Prefer the exchange's figure; leverage implies the margin before
+		// the change for an estimate
+		if r, ok := positionRisks(ctx, brk, symbol)[symbol]; ok && r.Liquidation > 0 {
+			fmt.Printf("    Liquidation: %s\n", formatLiquidation(symbol, r.Liquidation, false))
+		} else if position.Leverage > 0 {
+			margin := position.EntryPrice*position.Size/float64(position.Leverage) + amount
+			liquidation := risk.LiquidationPrice(position.Side, position.EntryPrice, position.Size, margin, e.maintenanceRate(accountName))
+			fmt.Printf("    Liquidation: %s\n", formatLiquidation(symbol, liquidation, true))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,398 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/agatticelli/strategy-go"
+	"github.com/agatticelli/trading-cli/internal/brokers"
+	"github.com/agatticelli/trading-cli/internal/config"
+	"github.com/agatticelli/trading-cli/internal/mirror"
+	"github.com/agatticelli/trading-cli/internal/ui"
+	types "github.com/agatticelli/trading-common-types"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+const (
+	// mirrorStrategy is the strategy name journaled for mirrored trades
+	mirrorStrategy = "mirror"
+	// defaultMirrorInterval is how often the leader is polled
+	defaultMirrorInterval = 10 * time.Second
+	// defaultMirrorDrift is the size drift, in percent, tolerated before
+	// a follower is resized
+	defaultMirrorDrift = 5.0
+)
+
+// MirrorOptions controls ExecuteMirror
+type MirrorOptions struct {
+	Leader       string
+	Followers    []config.MirrorFollower
+	Interval     time.Duration // Poll period (default 10s)
+	DriftPercent float64       // Size drift tolerated before resizing (default 5)
+	Once         bool          // Reconcile once and exit
+	DryRun       bool          // Print the reconciliation report without placing orders
+}
+
+// accountBook is an account's balance, positions and protection at one poll
+type accountBook struct {
+	balance *broker.Balance
+	equity  float64
+	book    mirror.Book
+}
+
+// ExecuteMirror copies the leader's positions, stops and take profits to
+// the followers, reconciling every interval until the context ends
+func (e *Executor) ExecuteMirror(ctx context.Context, opts MirrorOptions) error {
+	if _, ok := e.brokers[opts.Leader]; !ok {
+		return fmt.Errorf("leader account not found or not enabled: %s", opts.Leader)
+	}
+	if len(opts.Followers) == 0 {
+		return fmt.Errorf("no follower accounts")
+	}
+	for _, follower := range opts.Followers {
+		if _, ok := e.brokers[follower.Account]; !ok {
+			return fmt.Errorf("follower account not found or not enabled: %s", follower.Account)
+		}
+		if follower.Account == opts.Leader {
+			return fmt.Errorf("%s cannot follow itself", follower.Account)
+		}
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultMirrorInterval
+	}
+	if opts.DriftPercent <= 0 {
+		opts.DriftPercent = defaultMirrorDrift
+	}
+
+	if !opts.Once && !opts.DryRun {
+		fmt.Println(ui.Info(fmt.Sprintf("Mirroring %s to %d follower(s) every %s, resizing beyond %.1f%% drift (Ctrl+C to stop)",
+			opts.Leader, len(opts.Followers), opts.Interval, opts.DriftPercent)))
+	}
+
+	for pass := 0; ; pass++ {
+		// The first pass shows the full report; later ones only log orders
+		e.mirrorPass(ctx, opts, pass == 0)
+
+		if opts.Once || opts.DryRun {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("\n✓ Mirror stopped")
+			return nil
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+// mirrorPass reconciles every follower against one poll of the leader
+func (e *Executor) mirrorPass(ctx context.Context, opts MirrorOptions, report bool) {
+	stamp := time.Now().Format("15:04:05")
+
+	leader, err := e.fetchBook(ctx, opts.Leader)
+	if err != nil {
+		fmt.Printf("  ✗ %s leader %s: %v\n", stamp, opts.Leader, err)
+		return
+	}
+
+	// Followers only take on new exposure within the daily loss lockout
+	daily, err := e.observeDailyLoss(ctx)
+	if err != nil && !opts.DryRun {
+		fmt.Printf("  ⚠ %s daily loss check failed, no positions opened or added this pass: %v\n", stamp, err)
+	}
+	dailyErr := err
+
+	for _, follower := range opts.Followers {
+		brk := e.brokers[follower.Account]
+		e.loadPrecision(ctx, follower.Account, brk)
+
+		current, err := e.fetchBook(ctx, follower.Account)
+		if err != nil {
+			fmt.Printf("  ✗ %s %s: %v\n", stamp, follower.Account, err)
+			continue
+		}
+
+		ratio, source := mirrorRatio(follower, leader.equity, current.equity)
+		if ratio <= 0 {
+			fmt.Printf("  ✗ %s %s: no size ratio (%s)\n", stamp, follower.Account, source)
+			continue
+		}
+
+		lines := mirror.Plan(leader.book, current.book, ratio, opts.DriftPercent,
+			e.mirrorSizer(ctx, follower.Account, brk), e.mirrorPricer(ctx, follower.Account, brk))
+
+		if report {
+			fmt.Println(ui.Account(follower.Account))
+			fmt.Print(ui.FormatMirrorReport(fmt.Sprintf("%.4f (%s)", ratio, source), lines))
+		}
+		if opts.DryRun {
+			continue
+		}
+
+		locked := ""
+		switch {
+		case dailyErr != nil:
+			locked = "daily loss check failed"
+		case daily != nil:
+			locked = daily.Blocked(follower.Account)
+		}
+
+		for _, line := range lines {
+			for _, action := range line.Actions {
+				if locked != "" && (action.Kind == mirror.ActionOpen || action.Kind == mirror.ActionIncrease) {
+					fmt.Printf("  ⚠ %s %s %s: skipped %s, trading locked: %s\n", stamp, follower.Account, line.Symbol, ui.FormatMirrorAction(action), locked)
+					break
+				}
+				if err := e.applyMirrorAction(ctx, follower.Account, brk, current, action); err != nil {
+					fmt.Printf("  ✗ %s %s %s: %s failed: %v\n", stamp, follower.Account, line.Symbol, ui.FormatMirrorAction(action), err)
+					break
+				}
+				fmt.Printf("  ✓ %s %s %s: %s\n", stamp, follower.Account, line.Symbol, ui.FormatMirrorAction(action))
+			}
+		}
+	}
+}
+
+// fetchBook reads an account's equity, positions and protective orders
+func (e *Executor) fetchBook(ctx context.Context, accountName string) (*accountBook, error) {
+	brk := e.brokers[accountName]
+
+	balance, err := brk.GetBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+	positions, err := brk.GetPositions(ctx, &broker.PositionFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get positions: %w", err)
+	}
+	orders, err := brk.GetOrders(ctx, &broker.OrderFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+
+	return &accountBook{
+		balance: balance,
+		equity:  balance.Total + balance.UnrealizedPnL,
+		book:    mirror.NewBook(positions, orders),
+	}, nil
+}
+
+// mirrorRatio returns the follower's size ratio to the leader and how it
+// was derived
+func mirrorRatio(follower config.MirrorFollower, leaderEquity, followerEquity float64) (float64, string) {
+	if follower.Multiplier > 0 {
+		return follower.Multiplier, "multiplier"
+	}
+	if leaderEquity <= 0 {
+		return 0, "leader has no equity"
+	}
+	return followerEquity / leaderEquity, fmt.Sprintf("equity %s / %s", ui.FormatMoney(followerEquity), ui.FormatMoney(leaderEquity))
+}
+
+// mirrorSizer rounds follower sizes to the follower's lot step and
+// minimums, when the broker publishes them
+func (e *Executor) mirrorSizer(ctx context.Context, accountName string, brk broker.Broker) mirror.Sizer {
+	return func(symbol string, size, price float64) float64 {
+		info, err := e.symbolInfo(ctx, accountName, brk, symbol)
+		if err != nil || info == nil {
+			return size
+		}
+
+		rules := *info
+		if price == 0 {
+			rules.MinNotional = 0
+		}
+		size = rules.RoundSize(size)
+		if rules.CheckMinimums(size, price) != nil {
+			return 0
+		}
+		return size
+	}
+}
+
+// mirrorPricer rounds prices to the follower's tick size, when the broker
+// publishes it
+func (e *Executor) mirrorPricer(ctx context.Context, accountName string, brk broker.Broker) mirror.PriceRounder {
+	return func(symbol string, price float64) float64 {
+		info, err := e.symbolInfo(ctx, accountName, brk, symbol)
+		if err != nil || info == nil {
+			return price
+		}
+		return info.RoundPrice(price)
+	}
+}
+
+// applyMirrorAction places the orders for one reconciliation step
+func (e *Executor) applyMirrorAction(ctx context.Context, accountName string, brk broker.Broker, current *accountBook, action mirror.Action) error {
+	pos := &broker.Position{Symbol: action.Symbol, Side: action.Side}
+
+	switch action.Kind {
+	case mirror.ActionOpen, mirror.ActionIncrease:
+		return e.addMirrorExposure(ctx, accountName, brk, current.balance, action)
+
+	case mirror.ActionReduce:
+		_, err := brk.PlaceOrder(ctx, buildCloseRequest(pos, action.Size))
+		return err
+
+	case mirror.ActionClose:
+		if _, err := brk.PlaceOrder(ctx, buildCloseRequest(pos, action.Size)); err != nil {
+			return err
+		}
+		// Stops and targets left behind would be orphans
+		if err := brk.CancelAllOrders(ctx, action.Symbol); err != nil {
+			return err
+		}
+		delete(current.book.Orders, action.Symbol)
+		delete(current.book.Protection, action.Symbol)
+		return nil
+
+	case mirror.ActionProtect:
+		return e.replaceProtection(ctx, brk, current, action)
+
+	default:
+		return fmt.Errorf("unknown mirror action: %s", action.Kind)
+	}
+}
+
+// addMirrorExposure opens or adds to a follower position through the
+// same risk guard and journal as open, measuring risk to the leader's stop
+func (e *Executor) addMirrorExposure(ctx context.Context, accountName string, brk broker.Broker, balance *broker.Balance, action mirror.Action) error {
+	price, err := brk.GetCurrentPrice(ctx, action.Symbol)
+	if err != nil {
+		return fmt.Errorf("failed to get price: %w", err)
+	}
+
+	plan := &strategy.PositionPlan{
+		Symbol:        action.Symbol,
+		Side:          action.Side,
+		Size:          action.Size,
+		EntryPrice:    price,
+		Leverage:      action.Leverage,
+		NotionalValue: action.Size * price,
+	}
+	if action.Protection.Stop > 0 {
+		plan.StopLoss = &types.StopLossLevel{Price: action.Protection.Stop}
+		plan.RiskAmount = math.Abs(price-action.Protection.Stop) * action.Size
+	}
+	if action.Protection.TakeProfit > 0 {
+		plan.TakeProfits = []*types.TakeProfitLevel{{Price: action.Protection.TakeProfit}}
+	}
+
+	if err := e.checkRiskLimits(ctx, accountName, brk, balance, plan); err != nil {
+		return fmt.Errorf("blocked by risk guard: %w", err)
+	}
+
+	if action.Leverage > 0 {
+		leverageSide := "LONG"
+		if action.Side == broker.SideShort {
+			leverageSide = "SHORT"
+		}
+		if err := brk.SetLeverage(ctx, action.Symbol, leverageSide, action.Leverage); err != nil {
+			return fmt.Errorf("failed to set leverage: %w", err)
+		}
+	}
+	order, err := brk.PlaceOrder(ctx, &broker.OrderRequest{
+		Symbol: action.Symbol,
+		Side:   action.Side,
+		Type:   broker.OrderTypeMarket,
+		Size:   action.Size,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := e.journal.Record(journalEntry(accountName, mirrorStrategy, plan, order)); err != nil {
+		fmt.Printf("  ⚠ Failed to record trade in journal: %v\n", err)
+	}
+	return nil
+}
+
+// replaceProtection swaps the follower's stop and take profit for the
+// leader's. New legs are placed before the old ones are canceled, so the
+// position is never left without a stop; brokers that can't cancel single
+// orders get the old legs canceled first and the previous stop put back if
+// the new one is rejected.
+func (e *Executor) replaceProtection(ctx context.Context, brk broker.Broker, current *accountBook, action mirror.Action) error {
+	previous := current.book.Orders[action.Symbol]
+
+	canceler, ok := brk.(brokers.OrderCanceler)
+	if !ok {
+		if err := brk.CancelAllOrders(ctx, action.Symbol); err != nil {
+			return fmt.Errorf("failed to cancel orders: %w", err)
+		}
+		if _, err := placeProtection(ctx, brk, action, action.Protection); err != nil {
+			old := current.book.Protection[action.Symbol]
+			if old.Stop == 0 {
+				return err
+			}
+			if _, restoreErr := placeProtection(ctx, brk, action, mirror.Protection{Stop: old.Stop}); restoreErr != nil {
+				return fmt.Errorf("%w; previous stop not restored, position is unprotected: %v", err, restoreErr)
+			}
+			return fmt.Errorf("%w; previous stop restored", err)
+		}
+		return nil
+	}
+
+	placed, err := placeProtection(ctx, brk, action, action.Protection)
+	if err != nil {
+		// Keep the old legs and back out a half-placed replacement
+		for _, order := range placed {
+			canceler.CancelOrder(ctx, action.Symbol, order.ID)
+		}
+		return err
+	}
+	for _, order := range previous {
+		if err := canceler.CancelOrder(ctx, action.Symbol, order.ID); err != nil {
+			return fmt.Errorf("new SL/TP placed but failed to cancel order %s: %w", order.ID, err)
+		}
+	}
+	current.book.Orders[action.Symbol] = placed
+
+	return nil
+}
+
+// placeProtection places a reduce-only stop and take profit for the
+// action's position, returning the orders placed
+func placeProtection(ctx context.Context, brk broker.Broker, action mirror.Action, protection mirror.Protection) ([]*broker.Order, error) {
+	closeSide := broker.SideShort
+	if action.Side == broker.SideShort {
+		closeSide = broker.SideLong
+	}
+
+	var placed []*broker.Order
+	if protection.Stop > 0 {
+		order, err := brk.PlaceOrder(ctx, &broker.OrderRequest{
+			Symbol:     action.Symbol,
+			Side:       closeSide,
+			Type:       broker.OrderTypeStop,
+			Size:       action.Size,
+			StopPrice:  protection.Stop,
+			ReduceOnly: true,
+		})
+		if err != nil {
+			return placed, fmt.Errorf("failed to place stop loss: %w", err)
+		}
+		placed = append(placed, order)
+	}
+
+	if protection.TakeProfit > 0 {
+		order, err := brk.PlaceOrder(ctx, &broker.OrderRequest{
+			Symbol:     action.Symbol,
+			Side:       closeSide,
+			Type:       broker.OrderTypeTakeProfit,
+			Size:       action.Size,
+			Price:      protection.TakeProfit,
+			StopPrice:  protection.TakeProfit,
+			ReduceOnly: true,
+		})
+		if err != nil {
+			return placed, fmt.Errorf("failed to place take profit: %w", err)
+		}
+		placed = append(placed, order)
+	}
+
+	return placed, nil
+}
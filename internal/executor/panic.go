@@ -0,0 +1,228 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/ui"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// panicPollInterval is how often positions are re-queried while flattening
+const panicPollInterval = time.Second
+
+// panicResult is the verified outcome of flattening one account
+type panicResult struct {
+	account         string
+	ordersCanceled  int
+	positionsClosed int
+	remaining       []string // Symbols still open at the end
+	remainingOrders int
+	errors          []string
+	elapsed         time.Duration
+}
+
+// flat reports whether the account was verified flat
+func (r *panicResult) flat() bool {
+	return len(r.remaining) == 0 && r.remainingOrders == 0 && len(r.errors) == 0
+}
+
+// ExecutePanic concurrently cancels all orders and market-closes all
+// positions on every selected account, then re-queries until each account
+// is confirmed flat or the timeout expires.
+func (e *Executor) ExecutePanic(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fmt.Println(ui.Warning(fmt.Sprintf("Flattening %d account(s), timeout %s...", len(e.brokers), timeout)))
+
+	results := make([]*panicResult, 0, len(e.brokers))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for accountName, brk := range e.brokers {
+		wg.Add(1)
+		go func(accountName string, brk broker.Broker) {
+			defer wg.Done()
+			result := flattenAccount(ctx, accountName, brk)
+
+			mu.Lock()
+			results = append(results, result)
+			mu.Unlock()
+		}(accountName, brk)
+	}
+
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].account < results[j].account
+	})
+
+	fmt.Println(formatPanicSummary(results))
+
+	for _, result := range results {
+		if !result.flat() {
+			return fmt.Errorf("not all accounts verified flat")
+		}
+	}
+	return nil
+}
+
+// flattenAccount cancels orders, closes positions and verifies the account
+func flattenAccount(ctx context.Context, accountName string, brk broker.Broker) *panicResult {
+	start := time.Now()
+	result := &panicResult{account: accountName}
+	var mu sync.Mutex
+	addError := func(format string, args ...interface{}) {
+		mu.Lock()
+		result.errors = append(result.errors, fmt.Sprintf(format, args...))
+		mu.Unlock()
+	}
+
+	positions, err := brk.GetPositions(ctx, &broker.PositionFilter{})
+	if err != nil {
+		addError("get positions: %v", err)
+	}
+	orders, err := brk.GetOrders(ctx, &broker.OrderFilter{})
+	if err != nil {
+		addError("get orders: %v", err)
+	}
+
+	// Cancel every symbol with orders or a position; stops must go first so
+	// they can't fire against the closing orders
+	symbols := make(map[string]int) // Open orders per symbol
+	for _, order := range orders {
+		symbols[order.Symbol]++
+	}
+	for _, pos := range positions {
+		symbols[pos.Symbol] += 0
+	}
+
+	var wg sync.WaitGroup
+	for symbol, count := range symbols {
+		wg.Add(1)
+		go func(symbol string, count int) {
+			defer wg.Done()
+			if err := brk.CancelAllOrders(ctx, symbol); err != nil {
+				addError("cancel %s: %v", symbol, err)
+				return
+			}
+			mu.Lock()
+			result.ordersCanceled += count
+			mu.Unlock()
+		}(symbol, count)
+	}
+	wg.Wait()
+
+	// Close and re-query until flat. Re-sending reduce-only closes is safe:
+	// they can never open or flip a position.
+	initial := len(positions)
+	for {
+		for _, pos := range positions {
+			wg.Add(1)
+			go func(pos *broker.Position) {
+				defer wg.Done()
+				if _, err := brk.PlaceOrder(ctx, buildCloseRequest(pos, pos.Size)); err != nil {
+					addError("close %s: %v", pos.Symbol, err)
+				}
+			}(pos)
+		}
+		wg.Wait()
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(panicPollInterval):
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		current, err := brk.GetPositions(ctx, &broker.PositionFilter{})
+		if err != nil {
+			continue // Keep the last known positions and retry until the timeout
+		}
+		positions = current
+		if len(positions) == 0 {
+			break
+		}
+	}
+
+	// Final verification gets its own deadline so it still runs after a timeout
+	verifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	verified := true
+	if current, err := brk.GetPositions(verifyCtx, &broker.PositionFilter{}); err != nil {
+		addError("verify positions: %v", err)
+		verified = false
+	} else {
+		positions = current
+	}
+
+	for _, pos := range positions {
+		result.remaining = append(result.remaining, pos.Symbol)
+	}
+	if closed := initial - len(result.remaining); closed > 0 {
+		result.positionsClosed = closed
+	}
+
+	if remainingOrders, err := brk.GetOrders(verifyCtx, &broker.OrderFilter{}); err != nil {
+		addError("verify orders: %v", err)
+		verified = false
+	} else {
+		result.remainingOrders = len(remainingOrders)
+	}
+
+	// Errors from attempts that ultimately succeeded are noise
+	if verified && len(result.remaining) == 0 && result.remainingOrders == 0 {
+		result.errors = nil
+	}
+
+	result.elapsed = time.Since(start)
+	return result
+}
+
+// formatPanicSummary renders the per-account verification table
+func formatPanicSummary(results []*panicResult) string {
+	table := ui.NewTable("Account", "Orders Canceled", "Positions Closed", "Remaining", "Status", "Time")
+
+	var problems []string
+	for _, r := range results {
+		status := ui.SuccessStyle.Render(ui.IconSuccess + " FLAT")
+		if !r.flat() {
+			status = ui.ErrorStyle.Render(ui.IconError + " NOT FLAT")
+		}
+
+		remaining := ui.MutedStyle.Render("-")
+		if len(r.remaining) > 0 || r.remainingOrders > 0 {
+			remaining = ui.ErrorStyle.Render(fmt.Sprintf("%d pos / %d orders", len(r.remaining), r.remainingOrders))
+		}
+
+		table.AddRow(
+			ui.BoldStyle.Render(r.account),
+			fmt.Sprintf("%d", r.ordersCanceled),
+			fmt.Sprintf("%d", r.positionsClosed),
+			remaining,
+			status,
+			r.elapsed.Round(100*time.Millisecond).String(),
+		)
+
+		if len(r.remaining) > 0 {
+			problems = append(problems, fmt.Sprintf("%s still open: %s", r.account, strings.Join(r.remaining, ", ")))
+		}
+		for _, e := range r.errors {
+			problems = append(problems, fmt.Sprintf("%s: %s", r.account, e))
+		}
+	}
+
+	output := "\n" + table.Render()
+	for _, p := range problems {
+		output += ui.Error(p) + "\n"
+	}
+	return output
+}
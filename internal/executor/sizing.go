@@ -0,0 +1,77 @@
+package executor
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/agatticelli/strategy-go"
+	"github.com/agatticelli/trading-cli/internal/sizing"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// sizingBase returns the base and capital an account sizes against. An
+// explicit base (from --base) wins over the account and global config; a
+// fixed base without an amount uses the configured capital.
+func (e *Executor) sizingBase(accountName string, balance *broker.Balance, requested string) (sizing.Base, float64, error) {
+	settings := e.config.SizingFor(accountName)
+	if requested == "" {
+		requested = settings.Base
+	}
+
+	base, capital, err := sizing.ParseBase(requested)
+	if err != nil {
+		return "", 0, err
+	}
+	if base == sizing.BaseFixed && capital == 0 {
+		capital = settings.Capital
+	}
+
+	amount, err := base.Amount(balance, capital)
+	if err != nil {
+		return "", 0, fmt.Errorf("account %s: %w", accountName, err)
+	}
+	if amount <= 0 {
+		return "", 0, fmt.Errorf("no %s to size against", base.Label())
+	}
+	return base, amount, nil
+}
+
+// feesFor returns the costs an account pays on a trade. Limit entries pay
+// maker unless they cross the book (a long above the current price, a short
+// below it); stop exits always pay taker.
+func (e *Executor) feesFor(accountName string, side strategy.Side, entryPrice, currentPrice float64) sizing.Fees {
+	fees := sizing.Fees{
+		SlippageRate: e.config.Fees.SlippagePercent / 100,
+		FundingRate:  e.config.Fees.FundingBufferPercent / 100,
+	}
+
+	rates, ok := e.config.FeeRatesFor(accountName)
+	if !ok {
+		return fees
+	}
+
+	marketable := (side == strategy.SideLong && entryPrice >= currentPrice) ||
+		(side == strategy.SideShort && entryPrice <= currentPrice)
+
+	fees.EntryRate = rates.MakerPercent / 100
+	if marketable {
+		fees.EntryRate = rates.TakerPercent / 100
+	}
+	fees.ExitRate = rates.TakerPercent / 100
+
+	return fees
+}
+
+// settleFees itemizes the costs of a final (rounded) plan and folds them
+// into its risk amount, so the plan and journal show the true loss at the stop
+func settleFees(plan *strategy.PositionPlan, fees sizing.Fees) *sizing.FeeBreakdown {
+	if !fees.Enabled() {
+		return nil
+	}
+
+	breakdown := sizing.Breakdown(plan, fees)
+	if plan.StopLoss != nil {
+		plan.RiskAmount = math.Abs(plan.EntryPrice-plan.StopLoss.Price)*plan.Size + breakdown.Total
+	}
+	return &breakdown
+}
@@ -0,0 +1,178 @@
+package executor
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/analytics"
+	"github.com/agatticelli/trading-cli/internal/brokers"
+	"github.com/agatticelli/trading-cli/internal/history"
+	"github.com/agatticelli/trading-cli/internal/ui"
+)
+
+// StatsOptions selects the trades ExecuteStats analyzes
+type StatsOptions struct {
+	Symbol string // Empty for every symbol
+	Period history.Period
+	Format string // "" for tables, FormatJSON or FormatCSV
+}
+
+// ExecuteStats reports win rate, profit factor, expectancy, losing streaks
+// and risk-adjusted returns of closed trades, in total, per account and
+// per symbol
+func (e *Executor) ExecuteStats(ctx context.Context, opts StatsOptions) error {
+	accounts := e.collectHistory(ctx, opts.Symbol, opts.Period)
+
+	report := analytics.Report{
+		From:     opts.Period.From,
+		To:       opts.Period.To,
+		Accounts: []analytics.Group{},
+		Symbols:  []analytics.Group{},
+	}
+	all := []analytics.Outcome{}
+	bySymbol := make(map[string][]analytics.Outcome)
+	totalEquity := 0.0
+	equityKnown := true
+
+	for _, account := range accounts {
+		if account.Err != nil {
+			if opts.Format != "" {
+				return fmt.Errorf("account %s: %w", account.Account, account.Err)
+			}
+			fmt.Println(ui.Warning(fmt.Sprintf("%s left out: %v", account.Account, account.Err)))
+			continue
+		}
+
+		// Daily returns are taken against the realized balance, which is
+		// what closed trades move, as it stood at the end of the period
+		equity := e.equityAt(ctx, account.Account, opts.Period.To)
+		totalEquity += equity
+		equityKnown = equityKnown && equity > 0
+
+		outcomes := make([]analytics.Outcome, len(account.Trades))
+		for i, trade := range account.Trades {
+			outcomes[i] = tradeOutcome(trade)
+			bySymbol[trade.Symbol] = append(bySymbol[trade.Symbol], outcomes[i])
+		}
+		all = append(all, outcomes...)
+
+		report.Accounts = append(report.Accounts, analytics.NewGroup(account.Account, outcomes, report.From, report.To, equity))
+	}
+
+	if !equityKnown {
+		totalEquity = 0
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Closed.Before(all[j].Closed)
+	})
+	report.Total = analytics.NewGroup("TOTAL", all, report.From, report.To, totalEquity)
+
+	for symbol, outcomes := range bySymbol {
+		sort.SliceStable(outcomes, func(i, j int) bool {
+			return outcomes[i].Closed.Before(outcomes[j].Closed)
+		})
+		report.Symbols = append(report.Symbols, analytics.NewGroup(symbol, outcomes, report.From, report.To, 0))
+	}
+	sort.Slice(report.Symbols, func(i, j int) bool {
+		if report.Symbols[i].NetPnL != report.Symbols[j].NetPnL {
+			return report.Symbols[i].NetPnL > report.Symbols[j].NetPnL
+		}
+		return report.Symbols[i].Name < report.Symbols[j].Name
+	})
+
+	switch opts.Format {
+	case "":
+		fmt.Print(ui.FormatStats(report))
+		return nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	case FormatCSV:
+		return exportStatsCSV(report)
+	default:
+		return fmt.Errorf("unknown export format: %s (use json or csv)", opts.Format)
+	}
+}
+
+// equityAt is an account's realized balance at a past time: the current
+// balance with the PnL and quote fees of every fill since taken back out. Zero
+// when it can't be known.
+func (e *Executor) equityAt(ctx context.Context, accountName string, at time.Time) float64 {
+	brk := e.brokers[accountName]
+	balance, err := brk.GetBalance(ctx)
+	if err != nil {
+		return 0
+	}
+	equity := balance.Total
+
+	now := time.Now()
+	if !at.Before(now) {
+		return equity
+	}
+	provider, ok := brk.(brokers.FillProvider)
+	if !ok {
+		return 0
+	}
+	fills, err := provider.GetFills(ctx, "", at, now)
+	if err != nil {
+		return 0
+	}
+	for _, fill := range fills {
+		if !fill.Time.After(at) {
+			continue
+		}
+		equity -= fill.RealizedPnL
+		if fill.QuoteFee() {
+			equity += fill.Fee
+		}
+	}
+	return equity
+}
+
+// tradeOutcome converts a history trade for analytics
+func tradeOutcome(trade history.Trade) analytics.Outcome {
+	outcome := analytics.Outcome{PnL: trade.NetPnL, Closed: trade.ClosedAt}
+	if trade.R != nil {
+		outcome.R = *trade.R
+		outcome.HasR = true
+	}
+	return outcome
+}
+
+// exportStatsCSV writes one row per group: the total, then accounts, then
+// symbols
+func exportStatsCSV(report analytics.Report) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"scope", "name", "trades", "wins", "losses", "win_rate", "net_pnl", "avg_win", "avg_loss",
+		"profit_factor", "expectancy", "expectancy_r", "max_loss_streak", "sharpe", "sortino"})
+
+	write := func(scope string, g analytics.Group) {
+		w.Write([]string{
+			scope, g.Name, strconv.Itoa(g.Trades), strconv.Itoa(g.Wins), strconv.Itoa(g.Losses),
+			formatCSVFloat(g.WinRate), formatCSVFloat(g.NetPnL), formatCSVFloat(g.AvgWin), formatCSVFloat(g.AvgLoss),
+			formatCSVFloat(g.ProfitFactor), formatCSVFloat(g.Expectancy), formatCSVFloat(g.ExpectancyR),
+			strconv.Itoa(g.MaxLossStreak), formatCSVFloat(g.Sharpe), formatCSVFloat(g.Sortino),
+		})
+	}
+
+	write("total", report.Total)
+	for _, g := range report.Accounts {
+		write("account", g)
+	}
+	for _, g := range report.Symbols {
+		write("symbol", g)
+	}
+
+	w.Flush()
+	return w.Error()
+}
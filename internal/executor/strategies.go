@@ -0,0 +1,114 @@
+package executor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agatticelli/strategy-go"
+	"github.com/agatticelli/trading-cli/internal/config"
+	"github.com/agatticelli/trading-cli/internal/strategies"
+	"github.com/agatticelli/trading-cli/internal/ui"
+)
+
+// loadStrategies builds the default strategy and every strategy declared
+// in config
+func (e *Executor) loadStrategies(declared map[string]config.StrategyConfig) error {
+	defs := map[string]config.StrategyConfig{
+		DefaultStrategy: {Type: "riskratio"},
+	}
+	for name, def := range declared {
+		defs[name] = def
+	}
+
+	for name, def := range defs {
+		reg, err := strategies.Lookup(def.Type)
+		if err != nil {
+			return fmt.Errorf("strategy %s: %w", name, err)
+		}
+		strat, err := reg.Build(def.Params)
+		if err != nil {
+			return fmt.Errorf("strategy %s: %w", name, err)
+		}
+		e.strategies[name] = strat
+	}
+	e.strategyDefs = defs
+
+	return nil
+}
+
+// ratioStrategy builds the default strategy with a risk-reward ratio
+// given on the command instead of its configured one
+func ratioStrategy(rr float64) (strategy.Strategy, error) {
+	reg, err := strategies.Lookup(DefaultStrategy)
+	if err != nil {
+		return nil, err
+	}
+	return reg.Build(map[string]float64{"rr": rr})
+}
+
+// StrategyNames returns the configured strategy names in sorted order
+func (e *Executor) StrategyNames() []string {
+	names := make([]string, 0, len(e.strategies))
+	for name := range e.strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasStrategy reports whether a strategy name is configured
+func (e *Executor) HasStrategy(name string) bool {
+	_, ok := e.strategies[name]
+	return ok
+}
+
+// describeStrategy summarizes a non-default strategy for the position
+// plan, e.g. "scalp (riskratio, rr 3)"
+func (e *Executor) describeStrategy(name string) string {
+	if name == DefaultStrategy {
+		return ""
+	}
+	def := e.strategyDefs[name]
+	return fmt.Sprintf("%s (%s)", name, strings.Join(append([]string{def.Type}, strategyParams(def)...), ", "))
+}
+
+// strategyParams formats a strategy's parameters, defaults included
+func strategyParams(def config.StrategyConfig) []string {
+	reg, err := strategies.Lookup(def.Type)
+	if err != nil {
+		return nil
+	}
+	resolved, err := reg.Resolve(def.Params)
+	if err != nil {
+		return nil
+	}
+
+	params := make([]string, 0, len(reg.Params))
+	for _, param := range reg.Params {
+		params = append(params, fmt.Sprintf("%s %g", param.Name, resolved[param.Name]))
+	}
+	return params
+}
+
+// ExecuteListStrategies prints the strategies available to open
+func (e *Executor) ExecuteListStrategies() error {
+	table := ui.NewTable("Name", "Type", "Parameters", "Description")
+	for _, name := range e.StrategyNames() {
+		def := e.strategyDefs[name]
+		description := ""
+		if reg, err := strategies.Lookup(def.Type); err == nil {
+			description = reg.Description
+		}
+
+		label := name
+		if name == DefaultStrategy {
+			label += " (default)"
+		}
+		table.AddRow(label, def.Type, strings.Join(strategyParams(def), ", "), description)
+	}
+
+	fmt.Println(ui.Section("Strategies"))
+	fmt.Println(table.Render())
+	return nil
+}
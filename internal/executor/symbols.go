@@ -0,0 +1,139 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/agatticelli/strategy-go"
+	"github.com/agatticelli/trading-cli/internal/brokers"
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-cli/internal/symbols"
+	"github.com/agatticelli/trading-cli/internal/ui"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// symbolInfo returns the exchange rules for a symbol on an account. It
+// returns nil without error when the broker doesn't publish symbol rules.
+func (e *Executor) symbolInfo(ctx context.Context, accountName string, brk broker.Broker, symbol string) (*market.SymbolInfo, error) {
+	provider, ok := brk.(brokers.SymbolInfoProvider)
+	if !ok {
+		return nil, nil
+	}
+
+	info, err := e.symbols.Lookup(ctx, e.brokerNames[accountName], provider, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	ui.SetSymbolPrecision(info.Symbol, info.PriceDecimals(), info.SizeDecimals())
+	return info, nil
+}
+
+// ResolveSymbol turns user input such as "eth" or "ETHUSDT" into an
+// exchange symbol, validated against the symbols listed by the selected
+// accounts. Empty input resolves to empty (no symbol filter).
+func (e *Executor) ResolveSymbol(ctx context.Context, input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		return "", nil
+	}
+
+	resolver := symbols.NewResolver(e.config.Symbols.DefaultQuote, e.config.Symbols.Aliases)
+
+	known := []string{}
+	for accountName, brk := range e.brokers {
+		provider, ok := brk.(brokers.SymbolInfoProvider)
+		if !ok {
+			continue
+		}
+		listed, err := e.symbols.Symbols(ctx, e.brokerNames[accountName], provider)
+		if err != nil {
+			continue
+		}
+		for symbol := range listed {
+			known = append(known, symbol)
+		}
+	}
+	resolver.SetKnown(known)
+
+	return resolver.Resolve(input)
+}
+
+// loadPrecision registers display decimals for every symbol on an account.
+// Tables fall back to default decimals when rules can't be loaded.
+func (e *Executor) loadPrecision(ctx context.Context, accountName string, brk broker.Broker) {
+	provider, ok := brk.(brokers.SymbolInfoProvider)
+	if !ok {
+		return
+	}
+
+	listed, err := e.symbols.Symbols(ctx, e.brokerNames[accountName], provider)
+	if err != nil {
+		return
+	}
+
+	for _, info := range listed {
+		ui.SetSymbolPrecision(info.Symbol, info.PriceDecimals(), info.SizeDecimals())
+	}
+}
+
+// roundPlan snaps a plan to the exchange's tick size and lot step and
+// checks it against the symbol minimums. The stop rounds toward the entry
+// and sizes round down, so the rounded plan never risks more than the
+// original.
+func roundPlan(plan *strategy.PositionPlan, info *market.SymbolInfo) error {
+	var distance float64
+	if plan.StopLoss != nil {
+		distance = math.Abs(plan.EntryPrice - plan.StopLoss.Price)
+	}
+
+	plan.EntryPrice = info.RoundPrice(plan.EntryPrice)
+	if plan.StopLoss != nil {
+		plan.StopLoss.Price = info.RoundPriceToward(plan.StopLoss.Price, plan.EntryPrice)
+		if plan.StopLoss.Price == plan.EntryPrice {
+			return fmt.Errorf("stop loss rounds to the entry price at tick size %g", info.TickSize)
+		}
+	}
+	for _, tp := range plan.TakeProfits {
+		tp.Price = info.RoundPrice(tp.Price)
+	}
+
+	// The entry rounds to the nearest tick and may still move away from
+	// the stop; size down so the risk stays as planned
+	if plan.StopLoss != nil {
+		if rounded := math.Abs(plan.EntryPrice - plan.StopLoss.Price); rounded > distance {
+			plan.Size *= distance / rounded
+		}
+	}
+	plan.Size = info.RoundSize(plan.Size)
+	if err := info.CheckMinimums(plan.Size, plan.EntryPrice); err != nil {
+		return err
+	}
+
+	plan.NotionalValue = plan.Size * plan.EntryPrice
+	if plan.StopLoss != nil {
+		plan.RiskAmount = math.Abs(plan.EntryPrice-plan.StopLoss.Price) * plan.Size
+	}
+
+	return nil
+}
+
+// roundCloseSize rounds a partial close down to the lot step. A full close
+// is left as-is so dust isn't stranded.
+func roundCloseSize(info *market.SymbolInfo, size, positionSize float64) (float64, error) {
+	if info == nil || size >= positionSize {
+		return size, nil
+	}
+
+	rounded := info.RoundSize(size)
+	if rounded <= 0 || (info.MinQty > 0 && rounded < info.MinQty) {
+		return 0, fmt.Errorf("close size %s is below the %s minimum", ui.FormatSize(info.Symbol, size), info.Symbol)
+	}
+	return rounded, nil
+}
+
+// formatPrice renders a bare price at the symbol's precision
+func formatPrice(symbol string, price float64) string {
+	return fmt.Sprintf("%.*f", ui.PriceDecimals(symbol, price), price)
+}
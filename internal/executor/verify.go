@@ -0,0 +1,231 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/agatticelli/strategy-go"
+	"github.com/agatticelli/trading-cli/internal/risk"
+	"github.com/agatticelli/trading-cli/internal/ui"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+const (
+	// verifyAttempts is how many times orders are re-read after placement
+	verifyAttempts = 3
+	// verifyInterval gives the exchange time to register attached legs
+	verifyInterval = time.Second
+	// priceTolerance and sizeTolerance absorb exchange rounding
+	priceTolerance = 0.0005
+	sizeTolerance  = 0.01
+)
+
+// protection is the observed state of a freshly placed trade
+type protection struct {
+	entryPending bool // Entry still resting as an open order
+	filled       bool // The plan's side of the position grew after placement
+	stopLoss     *broker.Order
+	takeProfit   *broker.Order
+	problems     []string
+}
+
+// missingStop reports whether the plan wanted a stop that isn't there
+func (p *protection) missingStop(plan *strategy.PositionPlan) bool {
+	return plan.StopLoss != nil && p.stopLoss == nil
+}
+
+// missingTakeProfit reports whether the plan wanted a TP that isn't there
+func (p *protection) missingTakeProfit(plan *strategy.PositionPlan) bool {
+	return len(plan.TakeProfits) > 0 && p.takeProfit == nil
+}
+
+// positionSize returns the size held on one side of a symbol, zero when flat
+func positionSize(ctx context.Context, brk broker.Broker, symbol string, side broker.Side) (float64, error) {
+	position, err := brk.GetPosition(ctx, symbol)
+	if err != nil || position == nil || position.Side != side {
+		return 0, err
+	}
+	return position.Size, nil
+}
+
+// verifyProtection re-reads orders after placement and confirms the entry
+// and its SL/TP legs exist with the expected prices and sizes. before is
+// the plan side's position size ahead of placement, so a position that was
+// already open doesn't pass for a fill. When retry is set, missing legs on
+// a filled entry are attached as standalone reduce-only orders.
+func (e *Executor) verifyProtection(ctx context.Context, brk broker.Broker, plan *strategy.PositionPlan, entry *broker.Order, before float64, retry bool) {
+	state := e.inspectProtection(ctx, brk, plan, entry, before)
+
+	if state.entryPending && !state.filled && (state.missingStop(plan) || state.missingTakeProfit(plan)) {
+		// Attached legs on a resting entry only materialize once it fills
+		fmt.Println("  " + ui.Info("Entry pending; SL/TP legs not visible until fill. Run 'audit' after the fill to confirm protection."))
+		return
+	}
+
+	if !state.entryPending && !state.filled {
+		state.problems = append(state.problems, fmt.Sprintf("entry order %s not found and the %s position didn't grow (rejected or canceled?)", entry.ID, plan.Symbol))
+	}
+
+	if state.filled && retry && (state.missingStop(plan) || state.missingTakeProfit(plan)) {
+		e.reattachProtection(ctx, brk, plan, state)
+		state = e.inspectProtection(ctx, brk, plan, entry, before)
+	}
+
+	if state.missingStop(plan) {
+		state.problems = append(state.problems, fmt.Sprintf("STOP LOSS MISSING on %s - position is unprotected", plan.Symbol))
+	}
+	if state.missingTakeProfit(plan) {
+		state.problems = append(state.problems, fmt.Sprintf("take profit missing on %s", plan.Symbol))
+	}
+
+	if len(state.problems) == 0 {
+		fmt.Printf("  ✓ Verified: entry, stop loss and take profit in place\n")
+		return
+	}
+
+	for _, problem := range state.problems {
+		fmt.Println("  " + ui.ErrorStyle.Render(ui.IconWarning+" "+problem))
+	}
+	if state.filled && (state.missingStop(plan) || state.missingTakeProfit(plan)) && !retry {
+		fmt.Println(ui.MutedStyle.Render("    Re-run with --retry-protection to attach missing legs automatically"))
+	}
+}
+
+// inspectProtection polls open orders until all expected legs show up or
+// attempts run out. Only legs closing the plan's side at its stop or
+// target count, so legs of an earlier position aren't taken for this
+// trade's.
+func (e *Executor) inspectProtection(ctx context.Context, brk broker.Broker, plan *strategy.PositionPlan, entry *broker.Order, before float64) *protection {
+	var state *protection
+
+	for attempt := 0; attempt < verifyAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return state
+			case <-time.After(verifyInterval):
+			}
+		}
+
+		state = &protection{}
+
+		orders, err := brk.GetOrders(ctx, &broker.OrderFilter{Symbol: plan.Symbol})
+		if err != nil {
+			state.problems = append(state.problems, fmt.Sprintf("could not re-read orders: %v", err))
+			continue
+		}
+
+		size, err := positionSize(ctx, brk, plan.Symbol, plan.Side)
+		if err != nil {
+			state.problems = append(state.problems, fmt.Sprintf("could not re-read the %s position: %v", plan.Symbol, err))
+			continue
+		}
+		state.filled = size-before > plan.Size*sizeTolerance
+
+		for _, order := range orders {
+			if order.ID == entry.ID {
+				state.entryPending = true
+			}
+		}
+		if plan.StopLoss != nil {
+			if state.stopLoss = matchLeg(orders, plan, broker.OrderTypeStop, plan.StopLoss.Price); state.stopLoss != nil {
+				state.checkLeg("stop loss", state.stopLoss, plan.StopLoss.Price, plan.Size)
+			}
+		}
+		if len(plan.TakeProfits) > 0 {
+			if state.takeProfit = matchLeg(orders, plan, broker.OrderTypeTakeProfit, plan.TakeProfits[0].Price); state.takeProfit != nil {
+				state.checkLeg("take profit", state.takeProfit, plan.TakeProfits[0].Price, plan.Size)
+			}
+		}
+
+		if !state.missingStop(plan) && !state.missingTakeProfit(plan) {
+			break
+		}
+	}
+
+	return state
+}
+
+// matchLeg finds the plan's leg of a type among orders: one closing the
+// plan's side with its trigger at price, preferring one of the plan's
+// size. Nil when none matches.
+func matchLeg(orders []*broker.Order, plan *strategy.PositionPlan, orderType broker.OrderType, price float64) *broker.Order {
+	var match *broker.Order
+	for _, order := range orders {
+		if order.Type != orderType || order.Side == plan.Side {
+			continue
+		}
+		if math.Abs(risk.TriggerPrice(order)-price) > price*priceTolerance {
+			continue
+		}
+		if order.Size == 0 || math.Abs(order.Size-plan.Size) <= plan.Size*sizeTolerance {
+			return order
+		}
+		if match == nil {
+			match = order
+		}
+	}
+	return match
+}
+
+// checkLeg records price or size mismatches on a protective order
+func (p *protection) checkLeg(name string, order *broker.Order, expectedPrice, expectedSize float64) {
+	// Compare trigger prices; limit legs may carry a separate order price
+	price := order.StopPrice
+	if price == 0 {
+		price = order.Price
+	}
+
+	if math.Abs(price-expectedPrice) > expectedPrice*priceTolerance {
+		p.problems = append(p.problems, fmt.Sprintf("%s at %s, expected %s", name, formatPrice(order.Symbol, price), formatPrice(order.Symbol, expectedPrice)))
+	}
+
+	// Close-position orders report zero size and cover the whole position
+	if order.Size > 0 && math.Abs(order.Size-expectedSize) > expectedSize*sizeTolerance {
+		p.problems = append(p.problems, fmt.Sprintf("%s size %s, expected %s", name, ui.FormatSize(order.Symbol, order.Size), ui.FormatSize(order.Symbol, expectedSize)))
+	}
+}
+
+// reattachProtection places missing SL/TP legs as standalone reduce-only orders
+func (e *Executor) reattachProtection(ctx context.Context, brk broker.Broker, plan *strategy.PositionPlan, state *protection) {
+	closeSide := broker.SideShort
+	if plan.Side == broker.SideShort {
+		closeSide = broker.SideLong
+	}
+
+	if state.missingStop(plan) {
+		_, err := brk.PlaceOrder(ctx, &broker.OrderRequest{
+			Symbol:     plan.Symbol,
+			Side:       closeSide,
+			Type:       broker.OrderTypeStop,
+			Size:       plan.Size,
+			StopPrice:  plan.StopLoss.Price,
+			ReduceOnly: true,
+		})
+		if err != nil {
+			fmt.Printf("  ✗ Retry attaching stop loss failed: %v\n", err)
+		} else {
+			fmt.Printf("  ✓ Stop loss re-attached at %s\n", formatPrice(plan.Symbol, plan.StopLoss.Price))
+		}
+	}
+
+	if state.missingTakeProfit(plan) {
+		tp := plan.TakeProfits[0].Price
+		_, err := brk.PlaceOrder(ctx, &broker.OrderRequest{
+			Symbol:     plan.Symbol,
+			Side:       closeSide,
+			Type:       broker.OrderTypeTakeProfit,
+			Size:       plan.Size,
+			Price:      tp,
+			StopPrice:  tp,
+			ReduceOnly: true,
+		})
+		if err != nil {
+			fmt.Printf("  ✗ Retry attaching take profit failed: %v\n", err)
+		} else {
+			fmt.Printf("  ✓ Take profit re-attached at %s\n", formatPrice(plan.Symbol, tp))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package history
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is the time range a report covers
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// String renders the range as dates
+func (p Period) String() string {
+	return p.From.Local().Format("2006-01-02 15:04") + " → " + p.To.Local().Format("2006-01-02 15:04")
+}
+
+// Contains reports whether t falls within the range
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.From) && !t.After(p.To)
+}
+
+// ParsePeriod builds a range from --since and --until values. Each is a
+// date (2024-01-02), a timestamp (RFC 3339), "today", or a look-back such as
+// 24h, 7d or 4w; an empty until means now.
+func ParsePeriod(since, until string, now time.Time) (Period, error) {
+	to := now
+	if until != "" {
+		var err error
+		if to, err = parseInstant(until, now); err != nil {
+			return Period{}, fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+
+	from, err := parseInstant(since, now)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid --since: %w", err)
+	}
+	if !from.Before(to) {
+		return Period{}, fmt.Errorf("--since must be before --until")
+	}
+
+	return Period{From: from, To: to}, nil
+}
+
+// parseInstant reads a date, timestamp, "today" or a look-back from now
+func parseInstant(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if s == "today" {
+		y, m, d := now.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, now.Location()), nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(s)); err == nil {
+		return t, nil
+	}
+
+	if len(s) > 1 {
+		unit := map[byte]time.Duration{
+			'h': time.Hour,
+			'd': 24 * time.Hour,
+			'w': 7 * 24 * time.Hour,
+		}[s[len(s)-1]]
+		if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && unit > 0 && n > 0 {
+			return now.Add(-time.Duration(n) * unit), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%q is not a date, timestamp, today or a look-back like 7d", s)
+}
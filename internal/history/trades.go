@@ -0,0 +1,246 @@
+// Package history rebuilds round-trip trades from exchange fills: a trade
+// opens when a position leaves flat and closes when it returns to flat.
+package history
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// flatTolerance is the share of the opening size below which a position
+// counts as closed, absorbing float rounding across partial fills
+const flatTolerance = 1e-9
+
+// Trade is one round trip, from the first entry fill to the last exit fill
+type Trade struct {
+	Account  string      `json:"account"`
+	Symbol   string      `json:"symbol"`
+	Side     broker.Side `json:"side"`
+	OpenedAt time.Time   `json:"opened_at"`
+	ClosedAt time.Time   `json:"closed_at"`
+	Entry    float64     `json:"entry_price"` // Size-weighted average of entry fills
+	Exit     float64     `json:"exit_price"`  // Size-weighted average of exit fills
+	Size     float64     `json:"size"`
+	Fees     float64     `json:"fees"` // In the quote asset
+	// Fees paid in other assets (such as BNB), by asset. They are left out
+	// of Fees and NetPnL, which understate the cost of the trade.
+	OtherFees map[string]float64 `json:"other_fees,omitempty"`
+	PnL       float64            `json:"pnl"`     // Realized, before fees
+	NetPnL    float64            `json:"net_pnl"` // PnL - Fees
+	Risk      float64            `json:"risk,omitempty"`
+	R         *float64           `json:"r,omitempty"` // NetPnL / Risk, when the journal has the plan
+	Fills     int                `json:"fills"`
+	OrderID   string             `json:"order_id,omitempty"` // Order of the first entry fill
+}
+
+// Duration is how long the position was open
+func (t Trade) Duration() time.Duration {
+	return t.ClosedAt.Sub(t.OpenedAt)
+}
+
+// Result is what Reconstruct makes of a set of fills
+type Result struct {
+	Trades    []Trade // Closed round trips, by close time
+	Open      int     // Positions still open after the last fill
+	Unmatched int     // Fills of positions opened before the first fill
+}
+
+// position is a round trip being built
+type position struct {
+	trade     Trade
+	size      float64 // Open size, always positive
+	entryCost float64
+	exitCost  float64
+	exitSize  float64
+	preRange  bool // Opened before the first fill; closes without a trade
+}
+
+// Reconstruct pairs fills into round trips per symbol (and per position
+// side in hedge mode). Fills must belong to one account. held is the
+// signed one-way position per symbol after the last fill (see Held); the
+// fills are netted against it to find positions already open when the
+// range began, whose fills are counted as unmatched. In hedge mode those
+// show as exits against a flat position side.
+func Reconstruct(fills []market.Fill, held map[string]float64) Result {
+	sorted := make([]market.Fill, len(fills))
+	copy(sorted, fills)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	result := Result{Trades: []Trade{}}
+	open := openingPositions(sorted, held)
+
+	for _, fill := range sorted {
+		if fill.Quantity <= 0 {
+			continue
+		}
+		key := fill.Symbol + "/" + string(fill.PositionSide)
+		qty := fill.Quantity
+		pos := open[key]
+
+		// A flat position is entered in the fill's direction, except for
+		// a hedge-mode exit of a position that predates the range
+		if pos == nil {
+			if fill.PositionSide != "" && fill.PositionSide != fill.Side {
+				result.Unmatched++
+				continue
+			}
+			pos = newPosition(fill)
+			open[key] = pos
+			pos.add(fill, qty)
+			continue
+		}
+
+		if pos.preRange {
+			result.Unmatched++
+		}
+		if fill.Side == pos.trade.Side {
+			pos.add(fill, qty)
+			continue
+		}
+
+		closed := math.Min(qty, pos.size)
+		pos.reduce(fill, closed, qty)
+		if pos.size > pos.trade.Size*flatTolerance {
+			continue
+		}
+
+		if !pos.preRange {
+			result.Trades = append(result.Trades, pos.close())
+		}
+		delete(open, key)
+
+		// An exit larger than the position flips it (one-way mode only)
+		if rest := qty - closed; rest > qty*flatTolerance && fill.PositionSide == "" {
+			flipped := newPosition(fill)
+			flipped.add(fill, rest)
+			open[key] = flipped
+		}
+	}
+
+	result.Open = len(open)
+	sort.SliceStable(result.Trades, func(i, j int) bool {
+		return result.Trades[i].ClosedAt.Before(result.Trades[j].ClosedAt)
+	})
+	return result
+}
+
+// Held nets positions into the signed size held per symbol, long
+// positive, before the given later fills were made. With positions read
+// now and the fills since the end of a range, it is what Reconstruct needs
+// as held for that range.
+func Held(positions []*broker.Position, later []market.Fill) map[string]float64 {
+	held := make(map[string]float64, len(positions))
+	for _, pos := range positions {
+		held[pos.Symbol] += signed(pos.Side, pos.Size)
+	}
+	for _, fill := range later {
+		if fill.PositionSide == "" {
+			held[fill.Symbol] -= signed(fill.Side, fill.Quantity)
+		}
+	}
+	return held
+}
+
+// openingPositions finds the one-way positions open before the first
+// fill: what is held after the last fill less what the fills added
+func openingPositions(fills []market.Fill, held map[string]float64) map[string]*position {
+	start := make(map[string]float64)
+	volume := make(map[string]float64)
+	for _, fill := range fills {
+		if fill.PositionSide != "" || fill.Quantity <= 0 {
+			continue
+		}
+		start[fill.Symbol] -= signed(fill.Side, fill.Quantity)
+		volume[fill.Symbol] += fill.Quantity
+	}
+
+	open := make(map[string]*position)
+	for symbol, size := range start {
+		size += held[symbol]
+		if math.Abs(size) <= volume[symbol]*flatTolerance {
+			continue
+		}
+		side := broker.SideLong
+		if size < 0 {
+			side = broker.SideShort
+		}
+		open[symbol+"/"] = &position{
+			trade:    Trade{Symbol: symbol, Side: side, Size: math.Abs(size)},
+			size:     math.Abs(size),
+			preRange: true,
+		}
+	}
+	return open
+}
+
+// signed returns size as positive for longs and negative for shorts
+func signed(side broker.Side, size float64) float64 {
+	if side == broker.SideShort {
+		return -size
+	}
+	return size
+}
+
+func newPosition(fill market.Fill) *position {
+	return &position{trade: Trade{
+		Symbol:   fill.Symbol,
+		Side:     fill.Side,
+		OpenedAt: fill.Time,
+		OrderID:  fill.OrderID,
+	}}
+}
+
+// add grows the position by qty of an entry fill, charging its share of
+// the fill's fee
+func (p *position) add(fill market.Fill, qty float64) {
+	p.size += qty
+	p.entryCost += fill.Price * qty
+	p.trade.Size += qty
+	p.charge(fill, qty/fill.Quantity)
+	p.trade.Fills++
+}
+
+// reduce shrinks the position by the closed part of an exit fill of qty,
+// charging its share of the fill's fee
+func (p *position) reduce(fill market.Fill, closed, qty float64) {
+	p.size -= closed
+	p.exitCost += fill.Price * closed
+	p.exitSize += closed
+	p.charge(fill, closed/qty)
+	p.trade.ClosedAt = fill.Time
+	p.trade.Fills++
+}
+
+// charge adds a share of a fill's fee to the trade, to Fees when paid in
+// the quote asset and to OtherFees otherwise
+func (p *position) charge(fill market.Fill, share float64) {
+	if fill.QuoteFee() {
+		p.trade.Fees += fill.Fee * share
+		return
+	}
+	if p.trade.OtherFees == nil {
+		p.trade.OtherFees = make(map[string]float64)
+	}
+	p.trade.OtherFees[fill.FeeAsset] += fill.Fee * share
+}
+
+// close finalizes prices and PnL of a flat position
+func (p *position) close() Trade {
+	trade := p.trade
+	trade.Entry = p.entryCost / trade.Size
+	trade.Exit = p.exitCost / p.exitSize
+
+	direction := 1.0
+	if trade.Side == broker.SideShort {
+		direction = -1
+	}
+	trade.PnL = (trade.Exit - trade.Entry) * p.exitSize * direction
+	trade.NetPnL = trade.PnL - trade.Fees
+	return trade
+}
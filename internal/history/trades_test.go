@@ -0,0 +1,204 @@
+package history
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+var start = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+// fill is a one-way ETH-USDT fill at minute m with a quote fee
+func fill(m int, side broker.Side, qty, price, fee float64) market.Fill {
+	return market.Fill{
+		Symbol:   "ETH-USDT",
+		Side:     side,
+		Price:    price,
+		Quantity: qty,
+		Fee:      fee,
+		FeeAsset: "USDT",
+		Time:     start.Add(time.Duration(m) * time.Minute),
+	}
+}
+
+// hedged places a fill on a hedge-mode position side
+func hedged(f market.Fill, positionSide broker.Side) market.Fill {
+	f.PositionSide = positionSide
+	return f
+}
+
+// tradeSummary is the part of a Trade the tests check
+type tradeSummary struct {
+	Side      broker.Side
+	Entry     float64
+	Exit      float64
+	Size      float64
+	PnL       float64
+	Fees      float64
+	OtherFees map[string]float64
+	Fills     int
+}
+
+func TestReconstruct(t *testing.T) {
+	long, short := broker.SideLong, broker.SideShort
+
+	tests := []struct {
+		name      string
+		fills     []market.Fill
+		held      map[string]float64
+		trades    []tradeSummary
+		open      int
+		unmatched int
+	}{
+		{
+			name: "partial fills average into one trade",
+			fills: []market.Fill{
+				fill(0, long, 0.5, 100, 0.05),
+				fill(1, long, 0.5, 110, 0.05),
+				fill(2, short, 0.4, 120, 0.04),
+				fill(3, short, 0.6, 130, 0.06),
+			},
+			trades: []tradeSummary{
+				{Side: long, Entry: 105, Exit: 126, Size: 1, PnL: 21, Fees: 0.2, Fills: 4},
+			},
+		},
+		{
+			name: "exit larger than the position flips it and splits the fee",
+			fills: []market.Fill{
+				fill(0, long, 1, 100, 0.1),
+				fill(1, short, 2, 110, 0.4),
+			},
+			held: map[string]float64{"ETH-USDT": -1},
+			trades: []tradeSummary{
+				{Side: long, Entry: 100, Exit: 110, Size: 1, PnL: 10, Fees: 0.3, Fills: 2},
+			},
+			open: 1,
+		},
+		{
+			name: "hedge-mode sides are separate positions",
+			fills: []market.Fill{
+				hedged(fill(0, long, 1, 100, 0), long),
+				hedged(fill(1, short, 2, 100, 0), short),
+				hedged(fill(2, short, 1, 110, 0), long),
+				hedged(fill(3, long, 2, 90, 0), short),
+			},
+			trades: []tradeSummary{
+				{Side: long, Entry: 100, Exit: 110, Size: 1, PnL: 10, Fills: 2},
+				{Side: short, Entry: 100, Exit: 90, Size: 2, PnL: 20, Fills: 2},
+			},
+		},
+		{
+			name: "hedge-mode exit of a flat side predates the range",
+			fills: []market.Fill{
+				hedged(fill(0, short, 1, 110, 0), long),
+			},
+			unmatched: 1,
+		},
+		{
+			name: "fees in another asset are kept apart",
+			fills: []market.Fill{
+				fill(0, long, 1, 100, 0.1),
+				func() market.Fill {
+					f := fill(1, short, 1, 105, 0.002)
+					f.FeeAsset = "BNB"
+					return f
+				}(),
+			},
+			trades: []tradeSummary{
+				{Side: long, Entry: 100, Exit: 105, Size: 1, PnL: 5, Fees: 0.1, OtherFees: map[string]float64{"BNB": 0.002}, Fills: 2},
+			},
+		},
+		{
+			name: "break-even exit of a position opened before the range",
+			fills: []market.Fill{
+				fill(0, short, 1, 100, 0.1), // Reports no realized PnL
+				fill(1, long, 1, 100, 0.1),
+				fill(2, short, 1, 105, 0.1),
+			},
+			trades: []tradeSummary{
+				{Side: long, Entry: 100, Exit: 105, Size: 1, PnL: 5, Fees: 0.2, Fills: 2},
+			},
+			unmatched: 1,
+		},
+		{
+			name: "partial exit of a position opened before the range",
+			fills: []market.Fill{
+				fill(0, short, 0.5, 100, 0.05),
+			},
+			held:      map[string]float64{"ETH-USDT": 0.5},
+			open:      1,
+			unmatched: 1,
+		},
+		{
+			name: "pre-range position closed by a flipping fill",
+			fills: []market.Fill{
+				fill(0, long, 2, 100, 0.2), // Closes a short of 1, opens a long of 1
+				fill(1, short, 1, 110, 0.1),
+			},
+			trades: []tradeSummary{
+				{Side: long, Entry: 100, Exit: 110, Size: 1, PnL: 10, Fees: 0.2, Fills: 2},
+			},
+			unmatched: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Reconstruct(tt.fills, tt.held)
+
+			if result.Open != tt.open || result.Unmatched != tt.unmatched {
+				t.Errorf("open %d unmatched %d, want %d and %d", result.Open, result.Unmatched, tt.open, tt.unmatched)
+			}
+			if len(result.Trades) != len(tt.trades) {
+				t.Fatalf("got %d trades, want %d: %+v", len(result.Trades), len(tt.trades), result.Trades)
+			}
+			for i, want := range tt.trades {
+				trade := result.Trades[i]
+				got := tradeSummary{
+					Side:      trade.Side,
+					Entry:     round(trade.Entry),
+					Exit:      round(trade.Exit),
+					Size:      round(trade.Size),
+					PnL:       round(trade.PnL),
+					Fees:      round(trade.Fees),
+					OtherFees: trade.OtherFees,
+					Fills:     trade.Fills,
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("trade %d\n got %+v\nwant %+v", i, got, want)
+				}
+				if math.Abs(trade.NetPnL-(trade.PnL-trade.Fees)) > 1e-9 {
+					t.Errorf("trade %d: net %.4f, want PnL less fees", i, trade.NetPnL)
+				}
+			}
+		})
+	}
+}
+
+func TestHeld(t *testing.T) {
+	positions := []*broker.Position{
+		{Symbol: "ETH-USDT", Side: broker.SideLong, Size: 1},
+		{Symbol: "BTC-USDT", Side: broker.SideShort, Size: 0.2},
+	}
+	later := []market.Fill{
+		fill(0, broker.SideLong, 0.4, 100, 0),
+		hedged(fill(1, broker.SideLong, 5, 100, 0), broker.SideLong), // Hedge mode, not netted
+	}
+
+	got := Held(positions, later)
+	want := map[string]float64{"ETH-USDT": 0.6, "BTC-USDT": -0.2}
+	for symbol, size := range want {
+		if math.Abs(got[symbol]-size) > 1e-9 {
+			t.Errorf("%s: got %.4f, want %.4f", symbol, got[symbol], size)
+		}
+	}
+}
+
+// round drops float noise from averaged prices and split fees
+func round(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
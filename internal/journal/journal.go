@@ -0,0 +1,110 @@
+// Package journal records the plan behind every position opened by the
+// CLI, so later reports can express results in R (multiples of the risk
+// taken at entry).
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/store"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+const journalFile = "journal.jsonl"
+
+// Entry is the plan recorded when an order is placed
+type Entry struct {
+	At         time.Time   `json:"at"`
+	Account    string      `json:"account"`
+	Symbol     string      `json:"symbol"`
+	Side       broker.Side `json:"side"`
+	Size       float64     `json:"size"`
+	EntryPrice float64     `json:"entry_price"`
+	StopLoss   float64     `json:"stop_loss,omitempty"`
+	TakeProfit float64     `json:"take_profit,omitempty"`
+	RiskAmount float64     `json:"risk_amount"`
+	Strategy   string      `json:"strategy,omitempty"`
+	OrderID    string      `json:"order_id,omitempty"`
+}
+
+// Journal appends and queries entries in the local store
+type Journal struct {
+	store *store.Store
+}
+
+// New creates a journal backed by the given store
+func New(st *store.Store) *Journal {
+	return &Journal{store: st}
+}
+
+// Record appends an entry, stamping the time if unset
+func (j *Journal) Record(entry Entry) error {
+	if entry.At.IsZero() {
+		entry.At = time.Now().UTC()
+	}
+	return j.store.Append(journalFile, entry)
+}
+
+// Entries returns all recorded entries in insertion order
+func (j *Journal) Entries() ([]Entry, error) {
+	entries := []Entry{}
+	err := j.store.ReadLines(journalFile, func(line []byte) error {
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return fmt.Errorf("corrupt journal entry: %w", err)
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	return entries, err
+}
+
+// Latest returns the most recent entry for an account and symbol
+func (j *Journal) Latest(account, symbol string) (*Entry, error) {
+	entries, err := j.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Account == account && entries[i].Symbol == symbol {
+			return &entries[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// matchSlack allows for entries stamped after their order filled, since
+// market orders fill before PlaceOrder returns
+const matchSlack = time.Minute
+
+// matchWindow is how long before a trade opened a plan without a matching
+// order ID can have been recorded, which covers limit entries resting for
+// a day
+const matchWindow = 24 * time.Hour
+
+// Match finds the plan behind a trade among entries: the one recorded for
+// its opening order, else the latest for the account, symbol and side
+// recorded shortly before the trade opened. An entry that names another
+// order belongs to another trade and is never a fallback.
+func Match(entries []Entry, account, symbol, orderID string, side broker.Side, openedAt time.Time) *Entry {
+	var latest *Entry
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Account != account || entry.Symbol != symbol {
+			continue
+		}
+		if orderID != "" && entry.OrderID == orderID {
+			return entry
+		}
+		if entry.OrderID != "" && orderID != "" {
+			continue
+		}
+		if entry.Side == side && !entry.At.After(openedAt.Add(matchSlack)) && !entry.At.Before(openedAt.Add(-matchWindow)) {
+			latest = entry
+		}
+	}
+	return latest
+}
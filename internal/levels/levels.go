@@ -0,0 +1,167 @@
+// Package levels parses the entry, stop and target expressions accepted by
+// open ("mark", "-0.3%", "1.5%", "25", "atr:1.5") and resolves them to
+// absolute prices once the market data for an account is known.
+package levels
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// Kind is how an expression's value is interpreted
+type Kind string
+
+const (
+	KindPrice    Kind = "price"    // Absolute price; a stop or target below half the entry is a distance
+	KindMark     Kind = "mark"     // The current price (entries only)
+	KindPercent  Kind = "percent"  // Entries: signed offset from the current price; stops/targets: distance from entry
+	KindDistance Kind = "distance" // Price distance from the entry, whatever its size, written "+25"
+	KindATR      Kind = "atr"      // Multiple of ATR away from the entry (stops and targets)
+)
+
+// Expr is a parsed price expression
+type Expr struct {
+	Kind  Kind
+	Value float64
+}
+
+// Price returns an absolute price expression
+func Price(price float64) Expr {
+	return Expr{Kind: KindPrice, Value: price}
+}
+
+// Parse parses a price, "mark", a percentage such as "-0.3%" or "1.5%", a
+// distance such as "+25", or "atr:<multiple>"
+func Parse(s string) (Expr, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return Expr{}, fmt.Errorf("empty price")
+	}
+
+	if s == "mark" || s == "market" {
+		return Expr{Kind: KindMark}, nil
+	}
+
+	if multiple, ok := strings.CutPrefix(s, "atr:"); ok {
+		value, err := strconv.ParseFloat(multiple, 64)
+		if err != nil || value <= 0 {
+			return Expr{}, fmt.Errorf("invalid ATR multiple %q (e.g. atr:1.5)", multiple)
+		}
+		return Expr{Kind: KindATR, Value: value}, nil
+	}
+
+	if percent, ok := strings.CutSuffix(s, "%"); ok {
+		value, err := strconv.ParseFloat(percent, 64)
+		if err != nil || value == 0 || math.Abs(value) >= 100 {
+			return Expr{}, fmt.Errorf("invalid percentage %q", s)
+		}
+		return Expr{Kind: KindPercent, Value: value}, nil
+	}
+
+	if distance, ok := strings.CutPrefix(s, "+"); ok {
+		value, err := strconv.ParseFloat(distance, 64)
+		if err != nil || value <= 0 {
+			return Expr{}, fmt.Errorf("invalid distance %q (e.g. +25)", s)
+		}
+		return Expr{Kind: KindDistance, Value: value}, nil
+	}
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || value <= 0 {
+		return Expr{}, fmt.Errorf("invalid price %q (a price, mark, a percentage, +distance or atr:<multiple>)", s)
+	}
+	return Price(value), nil
+}
+
+// NeedsATR reports whether resolving the expression needs an ATR value
+func (e Expr) NeedsATR() bool {
+	return e.Kind == KindATR
+}
+
+// Relative reports whether the expression depends on market data or the
+// entry, i.e. is anything but an absolute price
+func (e Expr) Relative() bool {
+	return e.Kind != KindPrice
+}
+
+// Entry resolves an entry price against the current price
+func (e Expr) Entry(current float64) (float64, error) {
+	switch e.Kind {
+	case KindPrice:
+		return e.Value, nil
+	case KindMark:
+		return current, nil
+	case KindPercent:
+		return current * (1 + e.Value/100), nil
+	default:
+		return 0, fmt.Errorf("%s can't be used for an entry", e)
+	}
+}
+
+// Stop resolves a stop loss: below the entry for longs, above for shorts
+func (e Expr) Stop(side broker.Side, entry, atr float64) (float64, error) {
+	return e.resolve(side, entry, atr, -1)
+}
+
+// Target resolves a take profit: above the entry for longs, below for shorts
+func (e Expr) Target(side broker.Side, entry, atr float64) (float64, error) {
+	return e.resolve(side, entry, atr, 1)
+}
+
+// Distance reports whether a stop or target is a price distance from the
+// entry. No sensible level sits below half the entry, so "--sl 25" on a
+// 3950 entry means 25 away; "+25" is a distance whatever the entry.
+func (e Expr) Distance(entry float64) bool {
+	return e.Kind == KindDistance || (e.Kind == KindPrice && e.Value < entry/2)
+}
+
+// resolve applies the expression's distance in the given direction for a
+// long (1 = away from entry upward); shorts mirror it
+func (e Expr) resolve(side broker.Side, entry, atr float64, direction float64) (float64, error) {
+	if side == broker.SideShort {
+		direction = -direction
+	}
+
+	var distance float64
+	switch {
+	case e.Kind == KindPrice && !e.Distance(entry):
+		return e.Value, nil
+	case e.Distance(entry):
+		distance = e.Value
+	case e.Kind == KindPercent:
+		distance = entry * math.Abs(e.Value) / 100
+	case e.Kind == KindATR:
+		if atr <= 0 {
+			return 0, fmt.Errorf("ATR is unavailable")
+		}
+		distance = e.Value * atr
+	default:
+		return 0, fmt.Errorf("%s can't be used for a stop or target", e)
+	}
+
+	price := entry + direction*distance
+	if price <= 0 {
+		return 0, fmt.Errorf("%s resolves to a non-positive price", e)
+	}
+	return price, nil
+}
+
+// String renders the expression as it would be typed
+func (e Expr) String() string {
+	switch e.Kind {
+	case KindMark:
+		return "mark"
+	case KindPercent:
+		return strconv.FormatFloat(e.Value, 'f', -1, 64) + "%"
+	case KindATR:
+		return fmt.Sprintf("atr:%g", e.Value)
+	case KindDistance:
+		return "+" + strconv.FormatFloat(e.Value, 'f', -1, 64)
+	default:
+		return strconv.FormatFloat(e.Value, 'f', -1, 64)
+	}
+}
@@ -0,0 +1,140 @@
+package levels
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/agatticelli/trading-go/broker"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Expr
+		err   string
+	}{
+		{input: "3950", want: Expr{Kind: KindPrice, Value: 3950}},
+		{input: " 25 ", want: Expr{Kind: KindPrice, Value: 25}},
+		{input: "+25", want: Expr{Kind: KindDistance, Value: 25}},
+		{input: "mark", want: Expr{Kind: KindMark}},
+		{input: "MARKET", want: Expr{Kind: KindMark}},
+		{input: "-0.3%", want: Expr{Kind: KindPercent, Value: -0.3}},
+		{input: "1.5%", want: Expr{Kind: KindPercent, Value: 1.5}},
+		{input: "ATR:1.5", want: Expr{Kind: KindATR, Value: 1.5}},
+		{input: "", err: "empty price"},
+		{input: "0", err: "invalid price"},
+		{input: "-25", err: "invalid price"},
+		{input: "abc", err: "invalid price"},
+		{input: "+0", err: "invalid distance"},
+		{input: "0%", err: "invalid percentage"},
+		{input: "100%", err: "invalid percentage"},
+		{input: "atr:", err: "invalid ATR multiple"},
+		{input: "atr:-1", err: "invalid ATR multiple"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("got %v, %v; want an error containing %q", got, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			if again, err := Parse(got.String()); err != nil || again != got {
+				t.Errorf("String() %q parses back to %+v, %v", got.String(), again, err)
+			}
+		})
+	}
+}
+
+func TestStopAndTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		side   broker.Side
+		atr    float64
+		stop   float64
+		target float64
+		err    string
+	}{
+		{name: "absolute price", input: "3900", side: broker.SideLong, stop: 3900, target: 3900},
+		{name: "plain number is a distance", input: "25", side: broker.SideLong, stop: 3975, target: 4025},
+		{name: "plain number distance on a short", input: "25", side: broker.SideShort, stop: 4025, target: 3975},
+		{name: "explicit distance", input: "+25", side: broker.SideLong, stop: 3975, target: 4025},
+		{name: "percent of entry", input: "1.5%", side: broker.SideLong, stop: 3940, target: 4060},
+		{name: "percent sign ignored", input: "-1.5%", side: broker.SideShort, stop: 4060, target: 3940},
+		{name: "atr multiple", input: "atr:2", side: broker.SideLong, atr: 30, stop: 3940, target: 4060},
+		{name: "atr unavailable", input: "atr:2", side: broker.SideLong, err: "ATR is unavailable"},
+		{name: "distance past zero", input: "+5000", side: broker.SideLong, err: "non-positive price"},
+		{name: "mark is entry only", input: "mark", side: broker.SideLong, err: "can't be used for a stop"},
+	}
+
+	const entry = 4000
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("parse %q: %v", tt.input, err)
+			}
+
+			stop, err := expr.Stop(tt.side, entry, tt.atr)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("got %v, %v; want an error containing %q", stop, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("stop: %v", err)
+			}
+			target, err := expr.Target(tt.side, entry, tt.atr)
+			if err != nil {
+				t.Fatalf("target: %v", err)
+			}
+			if math.Abs(stop-tt.stop) > 1e-9 || math.Abs(target-tt.target) > 1e-9 {
+				t.Errorf("stop %g target %g, want %g and %g", stop, target, tt.stop, tt.target)
+			}
+		})
+	}
+}
+
+func TestEntry(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+		err   string
+	}{
+		{input: "3950", want: 3950},
+		{input: "mark", want: 4000},
+		{input: "-0.5%", want: 3980},
+		{input: "+25", err: "can't be used for an entry"},
+		{input: "atr:1", err: "can't be used for an entry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("parse %q: %v", tt.input, err)
+			}
+			got, err := expr.Entry(4000)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("got %v, %v; want an error containing %q", got, err, tt.err)
+				}
+				return
+			}
+			if err != nil || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("got %g, %v; want %g", got, err, tt.want)
+			}
+		})
+	}
+}
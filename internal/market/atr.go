@@ -0,0 +1,41 @@
+package market
+
+import (
+	"fmt"
+	"math"
+)
+
+// TrueRange returns the true range of a candle given the previous close
+func TrueRange(k Kline, prevClose float64) float64 {
+	tr := k.High - k.Low
+	if prevClose > 0 {
+		tr = math.Max(tr, math.Abs(k.High-prevClose))
+		tr = math.Max(tr, math.Abs(k.Low-prevClose))
+	}
+	return tr
+}
+
+// ATR computes Wilder's Average True Range over the given period using
+// the candles oldest first. It needs at least period+1 candles.
+func ATR(klines []Kline, period int) (float64, error) {
+	if period <= 0 {
+		return 0, fmt.Errorf("ATR period must be positive")
+	}
+	if len(klines) < period+1 {
+		return 0, fmt.Errorf("need at least %d candles for ATR(%d), got %d", period+1, period, len(klines))
+	}
+
+	// Seed with the simple average of the first period true ranges
+	atr := 0.0
+	for i := 1; i <= period; i++ {
+		atr += TrueRange(klines[i], klines[i-1].Close)
+	}
+	atr /= float64(period)
+
+	// Wilder smoothing over the rest
+	for i := period + 1; i < len(klines); i++ {
+		atr = (atr*float64(period-1) + TrueRange(klines[i], klines[i-1].Close)) / float64(period)
+	}
+
+	return atr, nil
+}
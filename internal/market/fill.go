@@ -0,0 +1,31 @@
+package market
+
+import (
+	"strings"
+	"time"
+
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// Fill is one execution of an order
+type Fill struct {
+	ID           string      `json:"id"`
+	OrderID      string      `json:"order_id"`
+	Symbol       string      `json:"symbol"`
+	Side         broker.Side `json:"side"`                    // LONG buys, SHORT sells
+	PositionSide broker.Side `json:"position_side,omitempty"` // Hedge-mode position; empty in one-way mode
+	Price        float64     `json:"price"`
+	Quantity     float64     `json:"quantity"`
+	Fee          float64     `json:"fee"` // Positive when paid
+	FeeAsset     string      `json:"fee_asset,omitempty"`
+	RealizedPnL  float64     `json:"realized_pnl,omitempty"` // As reported by the exchange, before fees
+	Time         time.Time   `json:"time"`
+}
+
+// QuoteFee reports whether the fee was paid in the symbol's quote asset
+// (USDT for BTC-USDT), the unit of PnL. Fees paid in another asset, such
+// as BNB at a discount, can't be netted against PnL without a price.
+func (f Fill) QuoteFee() bool {
+	_, quote, ok := strings.Cut(f.Symbol, "-")
+	return f.FeeAsset == "" || !ok || f.FeeAsset == quote
+}
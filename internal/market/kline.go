@@ -0,0 +1,14 @@
+// Package market holds exchange-agnostic market data types and indicators.
+package market
+
+import "time"
+
+// Kline is one OHLCV candle
+type Kline struct {
+	OpenTime time.Time `json:"open_time"`
+	Open     float64   `json:"open"`
+	High     float64   `json:"high"`
+	Low      float64   `json:"low"`
+	Close    float64   `json:"close"`
+	Volume   float64   `json:"volume"`
+}
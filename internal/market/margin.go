@@ -0,0 +1,37 @@
+package market
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// MarginMode is how a position's margin is backed
+type MarginMode string
+
+const (
+	MarginIsolated MarginMode = "isolated" // Only the position's own margin can be lost
+	MarginCross    MarginMode = "cross"    // The account's free balance backs every position
+)
+
+// PositionRisk is what an exchange reports about an open position's
+// liquidation beyond broker.Position
+type PositionRisk struct {
+	Symbol      string
+	Side        broker.Side
+	Liquidation float64    // Exchange's liquidation price; zero when it reports none
+	MarginMode  MarginMode // Empty when unknown
+}
+
+// ParseMarginMode parses a user or exchange margin mode
+func ParseMarginMode(s string) (MarginMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "isolated", "iso":
+		return MarginIsolated, nil
+	case "cross", "crossed":
+		return MarginCross, nil
+	default:
+		return "", fmt.Errorf("invalid margin mode: %s (use 'isolated' or 'cross')", s)
+	}
+}
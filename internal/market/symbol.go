@@ -0,0 +1,109 @@
+package market
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SymbolInfo holds an exchange's trading rules for one symbol. Zero values
+// mean the exchange imposes no rule.
+type SymbolInfo struct {
+	Symbol      string  `json:"symbol"`       // Normalized, e.g. BTC-USDT
+	BaseAsset   string  `json:"base_asset"`   // e.g. BTC
+	QuoteAsset  string  `json:"quote_asset"`  // e.g. USDT
+	TickSize    float64 `json:"tick_size"`    // Price increment
+	StepSize    float64 `json:"step_size"`    // Quantity increment
+	MinQty      float64 `json:"min_qty"`      // Smallest order size
+	MinNotional float64 `json:"min_notional"` // Smallest size × price
+}
+
+// roundingEpsilon absorbs float error such as 0.3/0.1 = 2.9999999999999996
+const roundingEpsilon = 1e-9
+
+// RoundPrice rounds a price to the nearest tick
+func (s *SymbolInfo) RoundPrice(price float64) float64 {
+	if s.TickSize <= 0 {
+		return price
+	}
+	return roundTo(math.Round(price/s.TickSize)*s.TickSize, s.PriceDecimals())
+}
+
+// RoundPriceToward rounds a price to a tick on the side of target, so a
+// stop rounded toward its entry never widens the risk
+func (s *SymbolInfo) RoundPriceToward(price, target float64) float64 {
+	if s.TickSize <= 0 {
+		return price
+	}
+	ticks := price / s.TickSize
+	if target > price {
+		ticks = math.Ceil(ticks - roundingEpsilon)
+	} else {
+		ticks = math.Floor(ticks + roundingEpsilon)
+	}
+	return roundTo(ticks*s.TickSize, s.PriceDecimals())
+}
+
+// RoundSize rounds a size down to the lot step, so rounding never adds risk
+func (s *SymbolInfo) RoundSize(size float64) float64 {
+	if s.StepSize <= 0 {
+		return size
+	}
+	return roundTo(math.Floor(size/s.StepSize+roundingEpsilon)*s.StepSize, s.SizeDecimals())
+}
+
+// PriceDecimals returns how many decimals a price of this symbol carries
+func (s *SymbolInfo) PriceDecimals() int {
+	return decimalsOf(s.TickSize)
+}
+
+// SizeDecimals returns how many decimals a size of this symbol carries
+func (s *SymbolInfo) SizeDecimals() int {
+	return decimalsOf(s.StepSize)
+}
+
+// CheckMinimums reports an error when an order is below the exchange minimums
+func (s *SymbolInfo) CheckMinimums(size, price float64) error {
+	if size <= 0 {
+		return fmt.Errorf("size rounds to zero at lot step %s", formatStep(s.StepSize))
+	}
+	if s.MinQty > 0 && size < s.MinQty-roundingEpsilon {
+		return fmt.Errorf("size %s is below the %s minimum of %s",
+			strconv.FormatFloat(size, 'f', s.SizeDecimals(), 64), s.Symbol, strconv.FormatFloat(s.MinQty, 'f', s.SizeDecimals(), 64))
+	}
+	if s.MinNotional > 0 && size*price < s.MinNotional-roundingEpsilon {
+		return fmt.Errorf("notional %.2f is below the %s minimum of %.2f", size*price, s.Symbol, s.MinNotional)
+	}
+	return nil
+}
+
+// decimalsOf returns the number of decimals in an increment such as 0.001
+func decimalsOf(step float64) int {
+	if step <= 0 {
+		return 0
+	}
+	formatted := strconv.FormatFloat(step, 'f', -1, 64)
+	if i := strings.IndexByte(formatted, '.'); i >= 0 {
+		return len(formatted) - i - 1
+	}
+	return 0
+}
+
+// DecimalsToStep converts a precision such as 3 into an increment such as 0.001
+func DecimalsToStep(decimals int) float64 {
+	if decimals < 0 {
+		return 0
+	}
+	step, _ := strconv.ParseFloat("1e-"+strconv.Itoa(decimals), 64)
+	return step
+}
+
+func roundTo(value float64, decimals int) float64 {
+	pow := math.Pow(10, float64(decimals))
+	return math.Round(value*pow) / pow
+}
+
+func formatStep(step float64) string {
+	return strconv.FormatFloat(step, 'f', -1, 64)
+}
@@ -0,0 +1,223 @@
+// Package mirror reconciles a follower account against a leader for copy
+// trading. Each pass compares the follower's positions and protective
+// orders with the leader's, scaled by a size ratio, and plans the orders
+// that bring the follower back in line. Working from the desired state
+// rather than replaying events means opens, closes, partial closes and
+// SL/TP changes are all caught the same way, including ones made while the
+// mirror was not running.
+package mirror
+
+import (
+	"math"
+	"sort"
+
+	"github.com/agatticelli/trading-cli/internal/risk"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// priceTolerance is the relative difference below which two trigger
+// prices count as equal, absorbing tick rounding
+const priceTolerance = 1e-6
+
+// Protection is a position's stop loss and take profit trigger prices,
+// zero when absent
+type Protection struct {
+	Stop       float64 `json:"stop,omitempty"`
+	TakeProfit float64 `json:"take_profit,omitempty"`
+}
+
+// Book is an account's positions and protection by symbol. Accounts are
+// expected in one-way mode: one position per symbol.
+type Book struct {
+	Positions  map[string]*broker.Position
+	Protection map[string]Protection
+	Orders     map[string][]*broker.Order // Stop and take profit orders
+}
+
+// NewBook indexes positions and their stop and take profit orders
+func NewBook(positions []*broker.Position, orders []*broker.Order) Book {
+	book := Book{
+		Positions:  make(map[string]*broker.Position, len(positions)),
+		Protection: make(map[string]Protection),
+		Orders:     make(map[string][]*broker.Order),
+	}
+	for _, pos := range positions {
+		book.Positions[pos.Symbol] = pos
+	}
+	for _, order := range orders {
+		if order.Type == broker.OrderTypeStop || order.Type == broker.OrderTypeTakeProfit {
+			book.Orders[order.Symbol] = append(book.Orders[order.Symbol], order)
+		}
+	}
+	for symbol, byType := range risk.OrdersBySymbol(orders) {
+		var p Protection
+		if stop := byType[broker.OrderTypeStop]; stop != nil {
+			p.Stop = risk.TriggerPrice(stop)
+		}
+		if tp := byType[broker.OrderTypeTakeProfit]; tp != nil {
+			p.TakeProfit = risk.TriggerPrice(tp)
+		}
+		book.Protection[symbol] = p
+	}
+	return book
+}
+
+// ActionKind is an order the follower needs
+type ActionKind string
+
+const (
+	ActionOpen     ActionKind = "open"     // Enter at market in the leader's direction
+	ActionIncrease ActionKind = "increase" // Add to the position at market
+	ActionReduce   ActionKind = "reduce"   // Partial close at market
+	ActionClose    ActionKind = "close"    // Full close at market, canceling leftover orders
+	ActionProtect  ActionKind = "protect"  // Replace the stop and take profit with the leader's
+)
+
+// Action is one step of a reconciliation
+type Action struct {
+	Kind       ActionKind  `json:"kind"`
+	Symbol     string      `json:"symbol"`
+	Side       broker.Side `json:"side"`           // Side of the position acted on
+	Size       float64     `json:"size,omitempty"` // Market order size, or protected size
+	Leverage   int         `json:"leverage,omitempty"`
+	Protection Protection  `json:"protection,omitempty"` // Legs to place; on opens, the stop the risk is measured to
+}
+
+// Line is the reconciliation of one symbol
+type Line struct {
+	Symbol       string      `json:"symbol"`
+	LeaderSide   broker.Side `json:"leader_side,omitempty"`
+	LeaderSize   float64     `json:"leader_size"`
+	Target       float64     `json:"target"` // Follower size wanted
+	FollowerSide broker.Side `json:"follower_side,omitempty"`
+	FollowerSize float64     `json:"follower_size"`
+	Drift        float64     `json:"drift_percent"` // Follower size off target, percent of target
+	Note         string      `json:"note,omitempty"`
+	Actions      []Action    `json:"actions,omitempty"`
+}
+
+// InSync reports whether the follower needs no orders for this symbol
+func (l Line) InSync() bool {
+	return len(l.Actions) == 0
+}
+
+// Sizer rounds a follower size to the symbol's lot step, returning zero
+// when it falls below the exchange minimums at price (a zero price skips
+// the notional minimum)
+type Sizer func(symbol string, size, price float64) float64
+
+// PriceRounder rounds a price to the follower's tick size
+type PriceRounder func(symbol string, price float64) float64
+
+// Plan compares a follower with the leader symbol by symbol. Follower
+// sizes are the leader's times ratio; size drift up to driftPercent is
+// tolerated so fluctuating equity ratios don't cause churn. Leader stops
+// and targets are rounded to the follower's tick before comparing, so a
+// coarser tick doesn't read as a change on every pass.
+func Plan(leader, follower Book, ratio, driftPercent float64, size Sizer, price PriceRounder) []Line {
+	symbols := make(map[string]bool)
+	for symbol := range leader.Positions {
+		symbols[symbol] = true
+	}
+	for symbol := range follower.Positions {
+		symbols[symbol] = true
+	}
+
+	lines := make([]Line, 0, len(symbols))
+	for symbol := range symbols {
+		lines = append(lines, planSymbol(symbol, leader, follower, ratio, driftPercent, size, price))
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].Symbol < lines[j].Symbol
+	})
+	return lines
+}
+
+func planSymbol(symbol string, leader, follower Book, ratio, driftPercent float64, size Sizer, price PriceRounder) Line {
+	lp, fp := leader.Positions[symbol], follower.Positions[symbol]
+	line := Line{Symbol: symbol}
+
+	if lp != nil {
+		line.LeaderSide, line.LeaderSize = lp.Side, lp.Size
+		mark := lp.MarkPrice
+		if mark == 0 {
+			mark = lp.EntryPrice
+		}
+		line.Target = size(symbol, lp.Size*ratio, mark)
+		if line.Target == 0 {
+			line.Note = "scaled size below the exchange minimum"
+		}
+	}
+	if fp != nil {
+		line.FollowerSide, line.FollowerSize = fp.Side, fp.Size
+	}
+
+	// Nothing to hold: close whatever the follower has
+	if line.Target == 0 {
+		if fp != nil {
+			line.Drift = 100
+			line.Actions = append(line.Actions, Action{Kind: ActionClose, Symbol: symbol, Side: fp.Side, Size: fp.Size})
+		}
+		return line
+	}
+
+	// Protection follows the leader's prices on the follower's tick
+	want, have := leader.Protection[symbol], follower.Protection[symbol]
+	if want.Stop > 0 {
+		want.Stop = price(symbol, want.Stop)
+	}
+	if want.TakeProfit > 0 {
+		want.TakeProfit = price(symbol, want.TakeProfit)
+	}
+
+	held, resized := line.Target, true
+	switch {
+	case fp == nil:
+		line.Drift = 100
+		line.Actions = append(line.Actions, Action{Kind: ActionOpen, Symbol: symbol, Side: lp.Side, Size: line.Target, Leverage: lp.Leverage, Protection: want})
+
+	case fp.Side != lp.Side:
+		line.Drift = 100
+		line.Note = "opposite side"
+		line.Actions = append(line.Actions,
+			Action{Kind: ActionClose, Symbol: symbol, Side: fp.Side, Size: fp.Size},
+			Action{Kind: ActionOpen, Symbol: symbol, Side: lp.Side, Size: line.Target, Leverage: lp.Leverage, Protection: want})
+
+	default:
+		diff := line.Target - fp.Size
+		line.Drift = math.Abs(diff) / line.Target * 100
+		held, resized = fp.Size, false
+		if line.Drift > driftPercent {
+			// Round the difference down so a resize never overshoots
+			if step := size(symbol, math.Abs(diff), 0); step > 0 {
+				kind := ActionIncrease
+				held = fp.Size + step
+				if diff < 0 {
+					kind = ActionReduce
+					held = fp.Size - step
+				}
+				action := Action{Kind: kind, Symbol: symbol, Side: lp.Side, Size: step}
+				if kind == ActionIncrease {
+					action.Protection = want
+				}
+				line.Actions = append(line.Actions, action)
+				resized = true
+			}
+		}
+	}
+
+	// Replace protection when prices moved or the size changed
+	if !samePrice(want.Stop, have.Stop) || !samePrice(want.TakeProfit, have.TakeProfit) || (resized && want != Protection{}) {
+		line.Actions = append(line.Actions, Action{Kind: ActionProtect, Symbol: symbol, Side: lp.Side, Size: held, Protection: want})
+	}
+
+	return line
+}
+
+// samePrice compares trigger prices, treating zero as absent
+func samePrice(a, b float64) bool {
+	if a == 0 || b == 0 {
+		return a == b
+	}
+	return math.Abs(a-b) <= math.Max(a, b)*priceTolerance
+}
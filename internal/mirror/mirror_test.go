@@ -0,0 +1,161 @@
+package mirror
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// lotSizer rounds down to a 0.01 lot with a 0.01 minimum
+func lotSizer(symbol string, size, price float64) float64 {
+	return math.Floor(size*100+1e-9) / 100
+}
+
+// halfTick rounds to a 0.5 tick, coarser than the leader's
+func halfTick(symbol string, price float64) float64 {
+	return math.Round(price*2) / 2
+}
+
+func position(side broker.Side, size float64) *broker.Position {
+	return &broker.Position{Symbol: "ETH-USDT", Side: side, Size: size, EntryPrice: 4000, MarkPrice: 4000, Leverage: 5}
+}
+
+func stop(side broker.Side, size, price float64) *broker.Order {
+	return &broker.Order{Symbol: "ETH-USDT", Side: side, Type: broker.OrderTypeStop, Size: size, StopPrice: price, ReduceOnly: true}
+}
+
+func TestPlan(t *testing.T) {
+	long, short := broker.SideLong, broker.SideShort
+
+	tests := []struct {
+		name     string
+		leader   Book
+		follower Book
+		drift    float64 // Follower drift percent
+		note     string
+		actions  []Action
+	}{
+		{
+			name:     "leader flat closes the follower",
+			leader:   NewBook(nil, nil),
+			follower: NewBook([]*broker.Position{position(long, 0.5)}, []*broker.Order{stop(short, 0.5, 3900)}),
+			drift:    100,
+			actions: []Action{
+				{Kind: ActionClose, Symbol: "ETH-USDT", Side: long, Size: 0.5},
+			},
+		},
+		{
+			name:     "leader below the minimum closes the follower",
+			leader:   NewBook([]*broker.Position{position(long, 0.01)}, nil),
+			follower: NewBook([]*broker.Position{position(long, 0.5)}, nil),
+			drift:    100,
+			note:     "scaled size below the exchange minimum",
+			actions: []Action{
+				{Kind: ActionClose, Symbol: "ETH-USDT", Side: long, Size: 0.5},
+			},
+		},
+		{
+			name:     "follower flat opens with the leader's protection",
+			leader:   NewBook([]*broker.Position{position(long, 1)}, []*broker.Order{stop(short, 1, 3900)}),
+			follower: NewBook(nil, nil),
+			drift:    100,
+			actions: []Action{
+				{Kind: ActionOpen, Symbol: "ETH-USDT", Side: long, Size: 0.5, Leverage: 5, Protection: Protection{Stop: 3900}},
+				{Kind: ActionProtect, Symbol: "ETH-USDT", Side: long, Size: 0.5, Protection: Protection{Stop: 3900}},
+			},
+		},
+		{
+			name:     "side flip closes then opens",
+			leader:   NewBook([]*broker.Position{position(long, 1)}, []*broker.Order{stop(short, 1, 3900)}),
+			follower: NewBook([]*broker.Position{position(short, 0.5)}, []*broker.Order{stop(long, 0.5, 4100)}),
+			drift:    100,
+			note:     "opposite side",
+			actions: []Action{
+				{Kind: ActionClose, Symbol: "ETH-USDT", Side: short, Size: 0.5},
+				{Kind: ActionOpen, Symbol: "ETH-USDT", Side: long, Size: 0.5, Leverage: 5, Protection: Protection{Stop: 3900}},
+				{Kind: ActionProtect, Symbol: "ETH-USDT", Side: long, Size: 0.5, Protection: Protection{Stop: 3900}},
+			},
+		},
+		{
+			name:     "drift inside tolerance is left alone",
+			leader:   NewBook([]*broker.Position{position(long, 1)}, []*broker.Order{stop(short, 1, 3900)}),
+			follower: NewBook([]*broker.Position{position(long, 0.49)}, []*broker.Order{stop(short, 0.49, 3900)}),
+			drift:    2,
+		},
+		{
+			name:     "drift outside tolerance increases and re-protects",
+			leader:   NewBook([]*broker.Position{position(long, 1)}, []*broker.Order{stop(short, 1, 3900)}),
+			follower: NewBook([]*broker.Position{position(long, 0.4)}, []*broker.Order{stop(short, 0.4, 3900)}),
+			drift:    20,
+			actions: []Action{
+				{Kind: ActionIncrease, Symbol: "ETH-USDT", Side: long, Size: 0.1, Protection: Protection{Stop: 3900}},
+				{Kind: ActionProtect, Symbol: "ETH-USDT", Side: long, Size: 0.5, Protection: Protection{Stop: 3900}},
+			},
+		},
+		{
+			name:     "drift outside tolerance reduces",
+			leader:   NewBook([]*broker.Position{position(long, 1)}, nil),
+			follower: NewBook([]*broker.Position{position(long, 0.6)}, nil),
+			drift:    20,
+			actions: []Action{
+				{Kind: ActionReduce, Symbol: "ETH-USDT", Side: long, Size: 0.1},
+			},
+		},
+		{
+			name:     "moved stop is replaced",
+			leader:   NewBook([]*broker.Position{position(long, 1)}, []*broker.Order{stop(short, 1, 3950)}),
+			follower: NewBook([]*broker.Position{position(long, 0.5)}, []*broker.Order{stop(short, 0.5, 3900)}),
+			actions: []Action{
+				{Kind: ActionProtect, Symbol: "ETH-USDT", Side: long, Size: 0.5, Protection: Protection{Stop: 3950}},
+			},
+		},
+		{
+			name:     "unchanged stop on a coarser tick needs nothing",
+			leader:   NewBook([]*broker.Position{position(long, 1)}, []*broker.Order{stop(short, 1, 3900.37)}),
+			follower: NewBook([]*broker.Position{position(long, 0.5)}, []*broker.Order{stop(short, 0.5, 3900.5)}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := Plan(tt.leader, tt.follower, 0.5, 5, lotSizer, halfTick)
+			if len(lines) != 1 {
+				t.Fatalf("got %d lines, want 1", len(lines))
+			}
+			line := lines[0]
+
+			if math.Abs(line.Drift-tt.drift) > 1e-6 {
+				t.Errorf("drift %.4f, want %.4f", line.Drift, tt.drift)
+			}
+			if line.Note != tt.note {
+				t.Errorf("note %q, want %q", line.Note, tt.note)
+			}
+			if !reflect.DeepEqual(line.Actions, tt.actions) {
+				t.Errorf("actions\n got %+v\nwant %+v", line.Actions, tt.actions)
+			}
+			if line.InSync() != (len(tt.actions) == 0) {
+				t.Errorf("InSync() = %v with %d actions", line.InSync(), len(tt.actions))
+			}
+		})
+	}
+}
+
+func TestPlanSortsSymbols(t *testing.T) {
+	leader := NewBook([]*broker.Position{
+		{Symbol: "SOL-USDT", Side: broker.SideLong, Size: 10, MarkPrice: 150},
+		{Symbol: "BTC-USDT", Side: broker.SideLong, Size: 0.1, MarkPrice: 60000},
+	}, nil)
+	follower := NewBook([]*broker.Position{
+		{Symbol: "ETH-USDT", Side: broker.SideShort, Size: 1, MarkPrice: 4000},
+	}, nil)
+
+	var symbols []string
+	for _, line := range Plan(leader, follower, 1, 5, lotSizer, halfTick) {
+		symbols = append(symbols, line.Symbol)
+	}
+	if want := []string{"BTC-USDT", "ETH-USDT", "SOL-USDT"}; !reflect.DeepEqual(symbols, want) {
+		t.Errorf("got %v, want %v", symbols, want)
+	}
+}
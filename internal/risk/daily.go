@@ -0,0 +1,171 @@
+package risk
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/config"
+	"github.com/agatticelli/trading-cli/internal/store"
+)
+
+const (
+	dailySessionFile = "daily_session.json"
+	overridesFile    = "overrides.jsonl"
+)
+
+// DailySession tracks start-of-day equity and lockouts for one session
+type DailySession struct {
+	Key         string             `json:"key"` // Session date (YYYY-MM-DD, shifted by reset hour)
+	StartedAt   time.Time          `json:"started_at"`
+	StartEquity map[string]float64 `json:"start_equity"`         // account -> equity at first observation
+	Locked      map[string]string  `json:"locked,omitempty"`     // account -> reason
+	AllLocked   string             `json:"all_locked,omitempty"` // Reason for an overall lockout
+}
+
+// DailyStatus is the evaluated state of the current session
+type DailyStatus struct {
+	Session  *DailySession
+	PnL      map[string]float64 // account -> equity change since session start
+	TotalPnL float64
+	ResetsAt time.Time
+}
+
+// Blocked returns why an account may not open trades, or "" when allowed
+func (s *DailyStatus) Blocked(account string) string {
+	if s.Session.AllLocked != "" {
+		return s.Session.AllLocked
+	}
+	return s.Session.Locked[account]
+}
+
+// Override is an audit record of a lockout being bypassed
+type Override struct {
+	At      time.Time `json:"at"`
+	Session string    `json:"session"`
+	Account string    `json:"account"`
+	Reason  string    `json:"reason"`
+	Action  string    `json:"action"`
+}
+
+// DailyTracker enforces daily loss limits using locally stored equity
+type DailyTracker struct {
+	store  *store.Store
+	limits config.DailyLossLimits
+	now    func() time.Time
+}
+
+// NewDailyTracker creates a tracker backed by the given store
+func NewDailyTracker(st *store.Store, limits config.DailyLossLimits) *DailyTracker {
+	return &DailyTracker{store: st, limits: limits, now: time.Now}
+}
+
+// Observe records current equity per account, starting a new session when
+// the reset hour has passed, and locks accounts whose losses breach a limit.
+// Lockouts persist until the next reset even if equity recovers.
+func (t *DailyTracker) Observe(equity map[string]float64) (*DailyStatus, error) {
+	now := t.now().UTC()
+	key, resetsAt := t.sessionKey(now)
+
+	session := &DailySession{}
+	if err := t.store.Load(dailySessionFile, session); err != nil {
+		return nil, err
+	}
+
+	if session.Key != key {
+		session = &DailySession{
+			Key:         key,
+			StartedAt:   now,
+			StartEquity: make(map[string]float64),
+		}
+	}
+	if session.Locked == nil {
+		session.Locked = make(map[string]string)
+	}
+
+	status := &DailyStatus{
+		Session:  session,
+		PnL:      make(map[string]float64),
+		ResetsAt: resetsAt,
+	}
+
+	totalStart := 0.0
+	for _, account := range sortedKeys(equity) {
+		current := equity[account]
+		start, ok := session.StartEquity[account]
+		if !ok {
+			session.StartEquity[account] = current
+			start = current
+		}
+
+		pnl := current - start
+		status.PnL[account] = pnl
+		status.TotalPnL += pnl
+		totalStart += start
+
+		if session.Locked[account] == "" {
+			if reason := t.breach(pnl, start, t.limits.MaxLossUSD, t.limits.MaxLossPercent); reason != "" {
+				session.Locked[account] = reason
+			}
+		}
+	}
+
+	if session.AllLocked == "" {
+		if reason := t.breach(status.TotalPnL, totalStart, t.limits.MaxTotalLossUSD, t.limits.MaxTotalLossPercent); reason != "" {
+			session.AllLocked = "all accounts: " + reason
+		}
+	}
+
+	if err := t.store.Save(dailySessionFile, session); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// RecordOverride appends an audit entry for a bypassed lockout
+func (t *DailyTracker) RecordOverride(status *DailyStatus, account, action string) error {
+	return t.store.Append(overridesFile, Override{
+		At:      t.now().UTC(),
+		Session: status.Session.Key,
+		Account: account,
+		Reason:  status.Blocked(account),
+		Action:  action,
+	})
+}
+
+// breach returns a description of the violated limit, or ""
+func (t *DailyTracker) breach(pnl, startEquity, maxUSD, maxPercent float64) string {
+	if pnl >= 0 {
+		return ""
+	}
+
+	loss := -pnl
+	if maxUSD > 0 && loss >= maxUSD {
+		return fmt.Sprintf("daily loss $%.2f reached limit $%.2f", loss, maxUSD)
+	}
+	if maxPercent > 0 && startEquity > 0 {
+		lossPercent := loss / startEquity * 100
+		if lossPercent >= maxPercent {
+			return fmt.Sprintf("daily loss %.2f%% reached limit %.2f%%", lossPercent, maxPercent)
+		}
+	}
+	return ""
+}
+
+// sessionKey returns the session the given time belongs to and when it ends
+func (t *DailyTracker) sessionKey(now time.Time) (string, time.Time) {
+	shifted := now.Add(-time.Duration(t.limits.ResetHourUTC) * time.Hour)
+	day := time.Date(shifted.Year(), shifted.Month(), shifted.Day(), 0, 0, 0, 0, time.UTC)
+	resetsAt := day.AddDate(0, 0, 1).Add(time.Duration(t.limits.ResetHourUTC) * time.Hour)
+	return day.Format("2006-01-02"), resetsAt
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
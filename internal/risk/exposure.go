@@ -0,0 +1,143 @@
+package risk
+
+import "github.com/agatticelli/trading-go/broker"
+
+// Exposure describes what an open position can lose if its stop is hit
+type Exposure struct {
+	Symbol     string
+	Side       broker.Side
+	Size       float64
+	EntryPrice float64
+	MarkPrice  float64
+	Notional   float64 // Size at mark price
+	StopPrice  float64 // Zero when the position has no stop
+	HasStop    bool
+	RiskAmount float64 // Loss at the stop; full notional when unprotected
+	Locked     float64 // Profit guaranteed by a stop beyond entry
+}
+
+// OrdersBySymbol indexes orders by symbol and type for quick lookup of a
+// position's TP/SL. When several orders share a type the last one wins.
+func OrdersBySymbol(orders []*broker.Order) map[string]map[broker.OrderType]*broker.Order {
+	orderMap := make(map[string]map[broker.OrderType]*broker.Order)
+	for _, order := range orders {
+		if orderMap[order.Symbol] == nil {
+			orderMap[order.Symbol] = make(map[broker.OrderType]*broker.Order)
+		}
+		orderMap[order.Symbol][order.Type] = order
+	}
+	return orderMap
+}
+
+// StopOrders maps each symbol to its stop-loss order
+func StopOrders(orders []*broker.Order) map[string]*broker.Order {
+	stops := make(map[string]*broker.Order)
+	for symbol, byType := range OrdersBySymbol(orders) {
+		if stop := byType[broker.OrderTypeStop]; stop != nil {
+			stops[symbol] = stop
+		}
+	}
+	return stops
+}
+
+// TriggerPrice returns the price at which an order executes
+func TriggerPrice(order *broker.Order) float64 {
+	if order.Price == 0 {
+		return order.StopPrice
+	}
+	return order.Price
+}
+
+// PositionExposures combines positions with their stop orders
+func PositionExposures(positions []*broker.Position, orders []*broker.Order) []Exposure {
+	stops := StopOrders(orders)
+
+	exposures := make([]Exposure, 0, len(positions))
+	for _, pos := range positions {
+		markPrice := pos.MarkPrice
+		if markPrice == 0 {
+			markPrice = pos.EntryPrice
+		}
+
+		exp := Exposure{
+			Symbol:     pos.Symbol,
+			Side:       pos.Side,
+			Size:       pos.Size,
+			EntryPrice: pos.EntryPrice,
+			MarkPrice:  markPrice,
+			Notional:   pos.Size * markPrice,
+		}
+
+		if stop, ok := stops[pos.Symbol]; ok {
+			exp.HasStop = true
+			exp.StopPrice = TriggerPrice(stop)
+
+			loss := LossAtPrice(pos.Side, pos.EntryPrice, exp.StopPrice, pos.Size)
+			if loss > 0 {
+				exp.RiskAmount = loss
+			} else {
+				exp.Locked = -loss
+			}
+		} else {
+			exp.RiskAmount = exp.Notional
+		}
+
+		exposures = append(exposures, exp)
+	}
+
+	return exposures
+}
+
+// PendingEntries lists resting entry orders (limit or market orders that
+// aren't reduce-only) as the exposure they add once filled: notional at
+// the order price and risk to the stop on the other side of the symbol,
+// or full notional without one
+func PendingEntries(orders []*broker.Order) []Exposure {
+	exposures := []Exposure{}
+	for _, order := range orders {
+		if order.ReduceOnly || (order.Type != broker.OrderTypeLimit && order.Type != broker.OrderTypeMarket) {
+			continue
+		}
+		price := TriggerPrice(order)
+		if price <= 0 || order.Size <= 0 {
+			continue
+		}
+
+		exp := Exposure{
+			Symbol:     order.Symbol,
+			Side:       order.Side,
+			Size:       order.Size,
+			EntryPrice: price,
+			MarkPrice:  price,
+			Notional:   order.Size * price,
+			RiskAmount: order.Size * price,
+		}
+		if stop := entryStop(orders, order); stop != nil {
+			exp.HasStop = true
+			exp.StopPrice = TriggerPrice(stop)
+			exp.RiskAmount = max(LossAtPrice(order.Side, price, exp.StopPrice, order.Size), 0)
+		}
+		exposures = append(exposures, exp)
+	}
+	return exposures
+}
+
+// entryStop finds the stop protecting an entry order: a stop on the same
+// symbol that closes the entry's side
+func entryStop(orders []*broker.Order, entry *broker.Order) *broker.Order {
+	for _, order := range orders {
+		if order.Symbol == entry.Symbol && order.Type == broker.OrderTypeStop && order.Side != entry.Side {
+			return order
+		}
+	}
+	return nil
+}
+
+// LossAtPrice returns the loss (positive) or gain (negative) of closing
+// a position of the given size at exitPrice
+func LossAtPrice(side broker.Side, entryPrice, exitPrice, size float64) float64 {
+	if side == broker.SideShort {
+		return (exitPrice - entryPrice) * size
+	}
+	return (entryPrice - exitPrice) * size
+}
@@ -0,0 +1,129 @@
+package risk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agatticelli/trading-cli/internal/config"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// Proposal is a trade about to be placed
+type Proposal struct {
+	Symbol     string
+	Side       broker.Side
+	Size       float64
+	EntryPrice float64
+	StopLoss   float64
+	Leverage   int
+}
+
+// Portfolio is the account state the guard evaluates a proposal against
+type Portfolio struct {
+	Equity    float64
+	Positions []*broker.Position
+	Orders    []*broker.Order
+}
+
+// BreachError lists every limit a proposal would violate
+type BreachError struct {
+	Violations []string
+}
+
+func (e *BreachError) Error() string {
+	return "risk limits breached: " + strings.Join(e.Violations, "; ")
+}
+
+// Guard enforces portfolio-wide limits before orders are placed
+type Guard struct {
+	limits config.RiskLimits
+}
+
+// NewGuard creates a guard for the given limits
+func NewGuard(limits config.RiskLimits) *Guard {
+	return &Guard{limits: limits}
+}
+
+// Enabled reports whether any limit is configured
+func (g *Guard) Enabled() bool {
+	l := g.limits
+	return l.MaxPositions > 0 || l.MaxOpenRiskPercent > 0 || l.MaxSymbolNotional > 0 || l.MaxLeverage > 0
+}
+
+// Check returns a *BreachError when the proposal breaches any limit
+func (g *Guard) Check(portfolio Portfolio, p Proposal) error {
+	var violations []string
+	l := g.limits
+
+	// Max leverage
+	if l.MaxLeverage > 0 && p.Leverage > l.MaxLeverage {
+		violations = append(violations, fmt.Sprintf(
+			"leverage %dx exceeds max %dx", p.Leverage, l.MaxLeverage))
+	}
+
+	// Resting entries count as the positions they open once filled
+	pending := PendingEntries(portfolio.Orders)
+
+	// Max concurrent positions (adding to an existing symbol doesn't open a new one)
+	if l.MaxPositions > 0 {
+		symbols := map[string]bool{p.Symbol: true}
+		for _, pos := range portfolio.Positions {
+			symbols[pos.Symbol] = true
+		}
+		for _, exp := range pending {
+			symbols[exp.Symbol] = true
+		}
+		if count := len(symbols); count > l.MaxPositions {
+			violations = append(violations, fmt.Sprintf(
+				"would hold %d positions (pending entries included), max is %d", count, l.MaxPositions))
+		}
+	}
+
+	// Max per-symbol notional
+	if l.MaxSymbolNotional > 0 {
+		notional := p.Size * p.EntryPrice
+		for _, exp := range append(PositionExposures(portfolio.Positions, nil), pending...) {
+			if exp.Symbol == p.Symbol {
+				notional += exp.Notional
+			}
+		}
+		if notional > l.MaxSymbolNotional {
+			violations = append(violations, fmt.Sprintf(
+				"%s notional $%.2f exceeds max $%.2f", p.Symbol, notional, l.MaxSymbolNotional))
+		}
+	}
+
+	// Max total open risk (unprotected positions count at full notional)
+	if l.MaxOpenRiskPercent > 0 {
+		if portfolio.Equity <= 0 {
+			violations = append(violations, "cannot evaluate open risk: equity is zero")
+		} else {
+			openRisk := p.Size * p.EntryPrice
+			if p.StopLoss > 0 {
+				openRisk = LossAtPrice(p.Side, p.EntryPrice, p.StopLoss, p.Size)
+			}
+			unprotected := []string{}
+			for _, exp := range append(PositionExposures(portfolio.Positions, portfolio.Orders), pending...) {
+				openRisk += exp.RiskAmount
+				if !exp.HasStop {
+					unprotected = append(unprotected, exp.Symbol)
+				}
+			}
+
+			riskPercent := openRisk / portfolio.Equity * 100
+			if riskPercent > l.MaxOpenRiskPercent {
+				msg := fmt.Sprintf("open risk would be $%.2f (%.2f%% of equity), max is %.2f%%",
+					openRisk, riskPercent, l.MaxOpenRiskPercent)
+				if len(unprotected) > 0 {
+					msg += fmt.Sprintf(" (no stop on %s, counted at full notional)", strings.Join(unprotected, ", "))
+				}
+				violations = append(violations, msg)
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &BreachError{Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package risk
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/agatticelli/trading-cli/internal/config"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+func TestGuardCheck(t *testing.T) {
+	ethLong := &broker.Position{Symbol: "ETH-USDT", Side: broker.SideLong, Size: 1, EntryPrice: 4000, MarkPrice: 4000}
+	ethStop := &broker.Order{Symbol: "ETH-USDT", Side: broker.SideShort, Type: broker.OrderTypeStop, Size: 1, StopPrice: 3900, ReduceOnly: true}
+	btcEntry := &broker.Order{Symbol: "BTC-USDT", Side: broker.SideLong, Type: broker.OrderTypeLimit, Size: 0.1, Price: 60000}
+	btcStop := &broker.Order{Symbol: "BTC-USDT", Side: broker.SideShort, Type: broker.OrderTypeStop, Size: 0.1, StopPrice: 59000, ReduceOnly: true}
+	btcTP := &broker.Order{Symbol: "BTC-USDT", Side: broker.SideShort, Type: broker.OrderTypeTakeProfit, Size: 0.1, StopPrice: 62000, ReduceOnly: true}
+
+	sol := Proposal{Symbol: "SOL-USDT", Side: broker.SideLong, Size: 10, EntryPrice: 150, StopLoss: 145, Leverage: 5}
+
+	tests := []struct {
+		name      string
+		limits    config.RiskLimits
+		portfolio Portfolio
+		proposal  Proposal
+		breach    string // Substring of the violation, empty for none
+	}{
+		{
+			name:     "no limits",
+			proposal: sol,
+		},
+		{
+			name:     "leverage above max",
+			limits:   config.RiskLimits{MaxLeverage: 3},
+			proposal: sol,
+			breach:   "leverage 5x exceeds max 3x",
+		},
+		{
+			name:      "new symbol within max positions",
+			limits:    config.RiskLimits{MaxPositions: 2},
+			portfolio: Portfolio{Positions: []*broker.Position{ethLong}},
+			proposal:  sol,
+		},
+		{
+			name:      "adding to a held symbol opens no position",
+			limits:    config.RiskLimits{MaxPositions: 1},
+			portfolio: Portfolio{Positions: []*broker.Position{ethLong}},
+			proposal:  Proposal{Symbol: "ETH-USDT", Side: broker.SideLong, Size: 1, EntryPrice: 4000},
+		},
+		{
+			name:      "pending entry counts as a position",
+			limits:    config.RiskLimits{MaxPositions: 2},
+			portfolio: Portfolio{Positions: []*broker.Position{ethLong}, Orders: []*broker.Order{btcEntry, btcStop}},
+			proposal:  sol,
+			breach:    "would hold 3 positions",
+		},
+		{
+			name:      "reduce-only orders are not entries",
+			limits:    config.RiskLimits{MaxPositions: 2},
+			portfolio: Portfolio{Positions: []*broker.Position{ethLong}, Orders: []*broker.Order{ethStop, btcTP}},
+			proposal:  sol,
+		},
+		{
+			name:      "pending entry counts toward symbol notional",
+			limits:    config.RiskLimits{MaxSymbolNotional: 10000},
+			portfolio: Portfolio{Orders: []*broker.Order{btcEntry}},
+			proposal:  Proposal{Symbol: "BTC-USDT", Side: broker.SideLong, Size: 0.1, EntryPrice: 60000},
+			breach:    "BTC-USDT notional $12000.00 exceeds max $10000.00",
+		},
+		{
+			name:      "other symbols don't count toward symbol notional",
+			limits:    config.RiskLimits{MaxSymbolNotional: 10000},
+			portfolio: Portfolio{Positions: []*broker.Position{ethLong}, Orders: []*broker.Order{btcEntry}},
+			proposal:  sol,
+		},
+		{
+			name:   "open risk within max",
+			limits: config.RiskLimits{MaxOpenRiskPercent: 3},
+			portfolio: Portfolio{
+				Equity:    10000,
+				Positions: []*broker.Position{ethLong},
+				Orders:    []*broker.Order{ethStop},
+			},
+			proposal: sol, // 100 + 50 = 1.5%
+		},
+		{
+			name:   "pending entry risks the distance to its stop",
+			limits: config.RiskLimits{MaxOpenRiskPercent: 2},
+			portfolio: Portfolio{
+				Equity:    10000,
+				Positions: []*broker.Position{ethLong},
+				Orders:    []*broker.Order{ethStop, btcEntry, btcStop},
+			},
+			proposal: sol, // 100 + 100 + 50 = 2.5%
+			breach:   "open risk would be $250.00 (2.50% of equity)",
+		},
+		{
+			name:      "pending entry without a stop counts at full notional",
+			limits:    config.RiskLimits{MaxOpenRiskPercent: 50},
+			portfolio: Portfolio{Equity: 10000, Orders: []*broker.Order{btcEntry}},
+			proposal:  sol,
+			breach:    "no stop on BTC-USDT",
+		},
+		{
+			name:     "open risk needs equity",
+			limits:   config.RiskLimits{MaxOpenRiskPercent: 2},
+			proposal: sol,
+			breach:   "equity is zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewGuard(tt.limits).Check(tt.portfolio, tt.proposal)
+			if tt.breach == "" {
+				if err != nil {
+					t.Fatalf("unexpected breach: %v", err)
+				}
+				return
+			}
+
+			var breach *BreachError
+			if !errors.As(err, &breach) {
+				t.Fatalf("got %v, want a breach containing %q", err, tt.breach)
+			}
+			if !strings.Contains(breach.Error(), tt.breach) {
+				t.Errorf("got %q, want it to contain %q", breach.Error(), tt.breach)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package risk
+
+import (
+	"math"
+
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// DefaultMaintenanceRate approximates the first-tier maintenance margin
+// rate of USDT perpetuals on the supported exchanges
+const DefaultMaintenanceRate = 0.005
+
+// maxLeverage bounds leverage searches
+const maxLeverage = 125
+
+// Liquidation holds estimated liquidation prices for a position. Zero
+// means the position can't be liquidated in that mode (e.g. a cross long
+// fully backed by collateral).
+type Liquidation struct {
+	Isolated float64 `json:"isolated"`
+	Cross    float64 `json:"cross"`
+}
+
+// IsolatedLiquidationPrice estimates where an isolated position is
+// liquidated: the loss has eaten the initial margin down to maintenance
+func IsolatedLiquidationPrice(side broker.Side, entry float64, leverage int, maintenanceRate float64) float64 {
+	if entry <= 0 || leverage <= 0 {
+		return 0
+	}
+	inverse := 1 / float64(leverage)
+
+	if side == broker.SideShort {
+		return entry * (1 + inverse) / (1 + maintenanceRate)
+	}
+	return math.Max(0, entry*(1-inverse)/(1-maintenanceRate))
+}
+
+// LiquidationPrice estimates where a position is liquidated given the
+// collateral backing it: the position's margin when isolated, or the equity
+// not needed as maintenance by other positions when cross
+func LiquidationPrice(side broker.Side, entry, size, collateral, maintenanceRate float64) float64 {
+	if entry <= 0 || size <= 0 {
+		return 0
+	}
+
+	if side == broker.SideShort {
+		return (entry*size + collateral) / (size * (1 + maintenanceRate))
+	}
+	return math.Max(0, (entry*size-collateral)/(size*(1-maintenanceRate)))
+}
+
+// CrossCollateral returns the equity left to back a new or existing cross
+// position after maintenance margin for the other positions
+func CrossCollateral(equity float64, others []*broker.Position, maintenanceRate float64) float64 {
+	collateral := equity
+	for _, pos := range others {
+		collateral -= pos.Size * pos.MarkPrice * maintenanceRate
+	}
+	return collateral
+}
+
+// StopBeyondLiquidation reports whether the stop would only be reached
+// after the position is liquidated
+func StopBeyondLiquidation(side broker.Side, stop, liquidation float64) bool {
+	if liquidation <= 0 || stop <= 0 {
+		return false
+	}
+	if side == broker.SideShort {
+		return stop >= liquidation
+	}
+	return stop <= liquidation
+}
+
+// MaxSafeLeverage returns the highest leverage whose isolated liquidation
+// lies beyond the stop, or 0 when no leverage does
+func MaxSafeLeverage(side broker.Side, entry, stop, maintenanceRate float64) int {
+	for leverage := maxLeverage; leverage >= 1; leverage-- {
+		liquidation := IsolatedLiquidationPrice(side, entry, leverage, maintenanceRate)
+		if !StopBeyondLiquidation(side, stop, liquidation) {
+			return leverage
+		}
+	}
+	return 0
+}
@@ -0,0 +1,133 @@
+package risk
+
+import (
+	"sort"
+
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// PositionRisk is one row of the portfolio heat report
+type PositionRisk struct {
+	Symbol     string      `json:"symbol"`
+	Side       broker.Side `json:"side"`
+	Size       float64     `json:"size"`
+	EntryPrice float64     `json:"entry_price"`
+	MarkPrice  float64     `json:"mark_price"`
+	Notional   float64     `json:"notional"`
+	StopPrice  float64     `json:"stop_price,omitempty"`
+	HasStop    bool        `json:"has_stop"`
+	AtRisk     float64     `json:"at_risk"`            // Loss if the stop is hit
+	Locked     float64     `json:"locked"`             // Profit guaranteed by the stop
+	RLocked    *float64    `json:"r_locked,omitempty"` // Locked profit in R, when the initial risk is known
+}
+
+// AccountRisk summarizes what an account loses if every stop is hit
+type AccountRisk struct {
+	Account             string         `json:"account"`
+	Equity              float64        `json:"equity"`
+	AtRisk              float64        `json:"at_risk"`
+	AtRiskPercent       float64        `json:"at_risk_percent"`
+	Locked              float64        `json:"locked"`
+	RLocked             float64        `json:"r_locked"`
+	Unprotected         []string       `json:"unprotected"`
+	UnprotectedNotional float64        `json:"unprotected_notional"`
+	LongNotional        float64        `json:"long_notional"`
+	ShortNotional       float64        `json:"short_notional"`
+	NetNotional         float64        `json:"net_notional"`
+	Positions           []PositionRisk `json:"positions"`
+	Error               string         `json:"error,omitempty"`
+}
+
+// Report is the portfolio heat across accounts
+type Report struct {
+	Accounts []AccountRisk `json:"accounts"`
+	Total    AccountRisk   `json:"total"`
+}
+
+// InitialRisk returns the $ risk taken when a position was opened, or 0
+// when unknown
+type InitialRisk func(symbol string) float64
+
+// BuildAccountRisk combines positions with their stop orders
+func BuildAccountRisk(account string, equity float64, positions []*broker.Position, orders []*broker.Order, initialRisk InitialRisk) AccountRisk {
+	report := AccountRisk{
+		Account:     account,
+		Equity:      equity,
+		Unprotected: []string{},
+		Positions:   []PositionRisk{},
+	}
+
+	for _, exp := range PositionExposures(positions, orders) {
+		row := PositionRisk{
+			Symbol:     exp.Symbol,
+			Side:       exp.Side,
+			Size:       exp.Size,
+			EntryPrice: exp.EntryPrice,
+			MarkPrice:  exp.MarkPrice,
+			Notional:   exp.Notional,
+			StopPrice:  exp.StopPrice,
+			HasStop:    exp.HasStop,
+			Locked:     exp.Locked,
+		}
+
+		if exp.HasStop {
+			row.AtRisk = exp.RiskAmount
+			report.AtRisk += exp.RiskAmount
+		} else {
+			report.Unprotected = append(report.Unprotected, exp.Symbol)
+			report.UnprotectedNotional += exp.Notional
+		}
+
+		if initialRisk != nil && exp.Locked > 0 {
+			if r := initialRisk(exp.Symbol); r > 0 {
+				locked := exp.Locked / r
+				row.RLocked = &locked
+				report.RLocked += locked
+			}
+		}
+		report.Locked += exp.Locked
+
+		if exp.Side == broker.SideShort {
+			report.ShortNotional += exp.Notional
+		} else {
+			report.LongNotional += exp.Notional
+		}
+
+		report.Positions = append(report.Positions, row)
+	}
+
+	report.NetNotional = report.LongNotional - report.ShortNotional
+	if equity > 0 {
+		report.AtRiskPercent = report.AtRisk / equity * 100
+	}
+
+	return report
+}
+
+// NewReport sorts account reports and computes the overall total
+func NewReport(accounts []AccountRisk) *Report {
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Account < accounts[j].Account
+	})
+
+	total := AccountRisk{Account: "TOTAL", Unprotected: []string{}, Positions: []PositionRisk{}}
+	for _, acc := range accounts {
+		total.Equity += acc.Equity
+		total.AtRisk += acc.AtRisk
+		total.Locked += acc.Locked
+		total.RLocked += acc.RLocked
+		total.UnprotectedNotional += acc.UnprotectedNotional
+		total.LongNotional += acc.LongNotional
+		total.ShortNotional += acc.ShortNotional
+		for _, symbol := range acc.Unprotected {
+			total.Unprotected = append(total.Unprotected, acc.Account+":"+symbol)
+		}
+	}
+
+	total.NetNotional = total.LongNotional - total.ShortNotional
+	if total.Equity > 0 {
+		total.AtRiskPercent = total.AtRisk / total.Equity * 100
+	}
+
+	return &Report{Accounts: accounts, Total: total}
+}
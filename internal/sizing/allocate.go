@@ -0,0 +1,113 @@
+package sizing
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Allocation is one account's part of a trade split across accounts
+type Allocation struct {
+	Account string
+	Weight  float64 // Equity or configured weight
+	Share   float64 // Fraction of the total after dropped accounts are redistributed
+	Size    float64 // Base units as placed: after fees and lot step rounding
+	Note    string  // Why the account gets nothing
+}
+
+// Allocated reports whether the account takes part in the trade
+func (a Allocation) Allocated() bool {
+	return a.Size > 0
+}
+
+// Sizer returns an account's final size for a share of the trade (fees
+// taken out and rounded to its lot step), or zero when that falls below
+// the account's exchange minimums
+type Sizer func(account string, share float64) float64
+
+// Allocate splits a trade across accounts in proportion to their weights.
+// An account whose part is below its minimums is dropped and its share
+// goes to the others, smallest weight first, so a split never leaves size
+// unplaced that another account could take. Accounts with notes are
+// reported but get nothing.
+func Allocate(weights map[string]float64, notes map[string]string, size Sizer) []Allocation {
+	names := make([]string, 0, len(weights)+len(notes))
+	for name := range weights {
+		names = append(names, name)
+	}
+	for name := range notes {
+		if _, ok := weights[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	allocations := make([]Allocation, len(names))
+	active := make([]bool, len(names))
+	remaining := 0
+	for i, name := range names {
+		allocations[i] = Allocation{Account: name, Weight: weights[name], Note: notes[name]}
+		switch {
+		case allocations[i].Note != "":
+		case allocations[i].Weight <= 0:
+			allocations[i].Note = "no weight"
+		default:
+			active[i] = true
+			remaining++
+		}
+	}
+
+	for remaining > 0 {
+		var sum float64
+		for i := range allocations {
+			if active[i] {
+				sum += allocations[i].Weight
+			}
+		}
+
+		// Size every active account; drop the lightest one below minimums
+		// and retry with its share spread over the rest
+		dropped := -1
+		for i := range allocations {
+			if !active[i] {
+				continue
+			}
+			allocations[i].Share = allocations[i].Weight / sum
+			allocations[i].Size = size(allocations[i].Account, allocations[i].Share)
+			if allocations[i].Size <= 0 && (dropped < 0 || allocations[i].Weight < allocations[dropped].Weight) {
+				dropped = i
+			}
+		}
+		if dropped < 0 {
+			break
+		}
+		active[dropped] = false
+		remaining--
+		allocations[dropped].Share, allocations[dropped].Size = 0, 0
+		allocations[dropped].Note = "share below the exchange minimum"
+	}
+
+	return allocations
+}
+
+// ParseWeights parses account weights such as "main=2,alt=1"
+func ParseWeights(s string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid weight %q (use account=weight)", part)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("invalid weight for %s: %q", name, value)
+		}
+		if _, dup := weights[name]; dup {
+			return nil, fmt.Errorf("duplicate weight for %s", name)
+		}
+		weights[name] = weight
+	}
+	return weights, nil
+}
@@ -0,0 +1,134 @@
+package sizing
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// minimumSizer places shares of a 1.0 trade, nothing below min
+func minimumSizer(min float64) Sizer {
+	return func(account string, share float64) float64 {
+		if share < min {
+			return 0
+		}
+		return share
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights map[string]float64
+		notes   map[string]string
+		min     float64
+		want    []Allocation
+	}{
+		{
+			name:    "split by weight",
+			weights: map[string]float64{"main": 2, "alt": 1},
+			min:     0.1,
+			want: []Allocation{
+				{Account: "alt", Weight: 1, Share: 1.0 / 3, Size: 1.0 / 3},
+				{Account: "main", Weight: 2, Share: 2.0 / 3, Size: 2.0 / 3},
+			},
+		},
+		{
+			name:    "share below minimum goes to the others",
+			weights: map[string]float64{"main": 6, "alt": 3.5, "tiny": 0.5},
+			min:     0.1,
+			want: []Allocation{
+				{Account: "alt", Weight: 3.5, Share: 3.5 / 9.5, Size: 3.5 / 9.5},
+				{Account: "main", Weight: 6, Share: 6 / 9.5, Size: 6 / 9.5},
+				{Account: "tiny", Weight: 0.5, Note: "share below the exchange minimum"},
+			},
+		},
+		{
+			name:    "drops cascade lightest first",
+			weights: map[string]float64{"main": 18, "alt": 1, "spare": 1},
+			min:     0.1,
+			want: []Allocation{
+				{Account: "alt", Weight: 1, Note: "share below the exchange minimum"},
+				{Account: "main", Weight: 18, Share: 1, Size: 1},
+				{Account: "spare", Weight: 1, Note: "share below the exchange minimum"},
+			},
+		},
+		{
+			name:    "every account dropped",
+			weights: map[string]float64{"main": 2, "alt": 1},
+			min:     2,
+			want: []Allocation{
+				{Account: "alt", Weight: 1, Note: "share below the exchange minimum"},
+				{Account: "main", Weight: 2, Note: "share below the exchange minimum"},
+			},
+		},
+		{
+			name:    "accounts with notes get nothing",
+			weights: map[string]float64{"main": 3, "alt": 1, "idle": 0},
+			notes:   map[string]string{"alt": "balance unavailable", "down": "broker error"},
+			min:     0.1,
+			want: []Allocation{
+				{Account: "alt", Weight: 1, Note: "balance unavailable"},
+				{Account: "down", Note: "broker error"},
+				{Account: "idle", Note: "no weight"},
+				{Account: "main", Weight: 3, Share: 1, Size: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Allocate(tt.weights, tt.notes, minimumSizer(tt.min))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d allocations, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				a := got[i]
+				if a.Account != want.Account || a.Weight != want.Weight || a.Note != want.Note {
+					t.Errorf("allocation %d: got %+v, want %+v", i, a, want)
+				}
+				if math.Abs(a.Share-want.Share) > 1e-9 || math.Abs(a.Size-want.Size) > 1e-9 {
+					t.Errorf("%s: share %.4f size %.4f, want %.4f and %.4f", a.Account, a.Share, a.Size, want.Share, want.Size)
+				}
+				if a.Allocated() != (want.Size > 0) {
+					t.Errorf("%s: Allocated() = %v", a.Account, a.Allocated())
+				}
+			}
+		})
+	}
+}
+
+func TestParseWeights(t *testing.T) {
+	tests := []struct {
+		input string
+		want  map[string]float64
+		err   string
+	}{
+		{input: "main=2,alt=1", want: map[string]float64{"main": 2, "alt": 1}},
+		{input: " main = 1.5 , alt=0.5 ", want: map[string]float64{"main": 1.5, "alt": 0.5}},
+		{input: "main=2,main=1", err: "duplicate weight for main"},
+		{input: "main", err: "invalid weight"},
+		{input: "=2", err: "invalid weight"},
+		{input: "main=0", err: "invalid weight for main"},
+		{input: "main=abc", err: "invalid weight for main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseWeights(tt.input)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("got %v, want an error containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
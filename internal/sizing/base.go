@@ -0,0 +1,64 @@
+package sizing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// Base is the capital a position's risk percent is taken from
+type Base string
+
+const (
+	BaseAvailable Base = "available" // Free margin; shrinks as other positions use margin
+	BaseEquity    Base = "equity"    // Total + unrealized PnL
+	BaseFixed     Base = "fixed"     // A configured notional capital
+)
+
+// ParseBase parses "available", "equity", "fixed" or a plain amount, which
+// selects the fixed base with that capital
+func ParseBase(s string) (Base, float64, error) {
+	switch b := Base(strings.ToLower(strings.TrimSpace(s))); b {
+	case "", BaseAvailable:
+		return BaseAvailable, 0, nil
+	case BaseEquity, BaseFixed:
+		return b, 0, nil
+	}
+
+	capital, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(s), "$"), 64)
+	if err != nil || capital <= 0 {
+		return "", 0, fmt.Errorf("invalid sizing base %q (use available, equity, fixed or an amount)", s)
+	}
+	return BaseFixed, capital, nil
+}
+
+// Amount returns the capital to size against for an account balance
+func (b Base) Amount(balance *broker.Balance, capital float64) (float64, error) {
+	switch b {
+	case "", BaseAvailable:
+		return balance.Available, nil
+	case BaseEquity:
+		return balance.Total + balance.UnrealizedPnL, nil
+	case BaseFixed:
+		if capital <= 0 {
+			return 0, fmt.Errorf("fixed sizing base needs a capital amount")
+		}
+		return capital, nil
+	default:
+		return 0, fmt.Errorf("unknown sizing base: %s", b)
+	}
+}
+
+// Label names the base for the position plan
+func (b Base) Label() string {
+	switch b {
+	case BaseEquity:
+		return "equity"
+	case BaseFixed:
+		return "fixed capital"
+	default:
+		return "available balance"
+	}
+}
@@ -0,0 +1,84 @@
+// Package sizing adjusts strategy position plans for costs the strategy
+// doesn't model, so a stopped-out trade loses what was asked for and no more.
+package sizing
+
+import (
+	"math"
+
+	"github.com/agatticelli/strategy-go"
+)
+
+// Fees are trading costs as fractions of notional (0.0005 = 0.05%)
+type Fees struct {
+	EntryRate    float64 // Maker for resting limit entries, taker when the entry crosses the book
+	ExitRate     float64 // Taker; stops close at market
+	SlippageRate float64 // Buffer for the stop filling worse than its trigger
+	FundingRate  float64 // Buffer reserved for funding paid while the trade is open
+}
+
+// FeeBreakdown is the cost of a round trip that ends at the stop
+type FeeBreakdown struct {
+	EntryFee float64
+	ExitFee  float64
+	Slippage float64
+	Funding  float64
+	Total    float64
+}
+
+// Enabled reports whether any cost is configured
+func (f Fees) Enabled() bool {
+	return f.EntryRate > 0 || f.ExitRate > 0 || f.SlippageRate > 0 || f.FundingRate > 0
+}
+
+// costPerUnit returns the cost of one unit entered at entry and stopped at stop
+func (f Fees) costPerUnit(entry, stop float64) float64 {
+	return entry*f.EntryRate + stop*(f.ExitRate+f.SlippageRate) + entry*f.FundingRate
+}
+
+// ApplyFees shrinks the plan's size so that the loss at the stop plus
+// entry fee, exit fee and buffers fits inside the plan's risk amount
+func ApplyFees(plan *strategy.PositionPlan, fees Fees) {
+	if !fees.Enabled() || plan.StopLoss == nil || plan.Size <= 0 {
+		return
+	}
+
+	priceRisk := math.Abs(plan.EntryPrice - plan.StopLoss.Price)
+	if priceRisk == 0 {
+		return
+	}
+
+	budget := plan.RiskAmount
+	if budget <= 0 {
+		budget = priceRisk * plan.Size
+	}
+
+	plan.Size = fees.SizeFor(budget, plan.EntryPrice, plan.StopLoss.Price)
+	plan.NotionalValue = plan.Size * plan.EntryPrice
+}
+
+// SizeFor returns the size whose loss at the stop plus entry fee, exit fee
+// and buffers equals budget
+func (f Fees) SizeFor(budget, entry, stop float64) float64 {
+	priceRisk := math.Abs(entry - stop)
+	if priceRisk == 0 {
+		return 0
+	}
+	return budget / (priceRisk + f.costPerUnit(entry, stop))
+}
+
+// Breakdown itemizes the costs of the plan as sized
+func Breakdown(plan *strategy.PositionPlan, fees Fees) FeeBreakdown {
+	stop := plan.EntryPrice
+	if plan.StopLoss != nil {
+		stop = plan.StopLoss.Price
+	}
+
+	b := FeeBreakdown{
+		EntryFee: plan.Size * plan.EntryPrice * fees.EntryRate,
+		ExitFee:  plan.Size * stop * fees.ExitRate,
+		Slippage: plan.Size * stop * fees.SlippageRate,
+		Funding:  plan.Size * plan.EntryPrice * fees.FundingRate,
+	}
+	b.Total = b.EntryFee + b.ExitFee + b.Slippage + b.Funding
+	return b
+}
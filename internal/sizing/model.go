@@ -0,0 +1,75 @@
+package sizing
+
+import (
+	"fmt"
+	"math"
+)
+
+// Model is how the user asked for the position to be sized
+type Model string
+
+const (
+	ModelRiskPercent Model = "risk_percent" // Percent of the sizing balance lost at the stop
+	ModelRiskUSD     Model = "risk_usd"     // Dollars lost at the stop
+	ModelNotional    Model = "notional"     // Position value in dollars
+	ModelQuantity    Model = "quantity"     // Position size in base units
+)
+
+// Request is a sizing model with its value
+type Request struct {
+	Model Model
+	Value float64
+}
+
+// RiskPercent converts the request into the percent of balance the
+// strategy sizes with, so every model yields the same plan fields
+func (r Request) RiskPercent(balance, entry, stop float64) (float64, error) {
+	if r.Value <= 0 {
+		return 0, fmt.Errorf("sizing value must be positive")
+	}
+	if r.Model == ModelRiskPercent {
+		return r.Value, nil
+	}
+
+	if balance <= 0 {
+		return 0, fmt.Errorf("no balance to size against")
+	}
+	distance := math.Abs(entry - stop)
+
+	var riskAmount float64
+	switch r.Model {
+	case ModelRiskUSD:
+		riskAmount = r.Value
+	case ModelNotional:
+		if entry <= 0 {
+			return 0, fmt.Errorf("entry price is required for notional sizing")
+		}
+		riskAmount = r.Value / entry * distance
+	case ModelQuantity:
+		riskAmount = r.Value * distance
+	default:
+		return 0, fmt.Errorf("unknown sizing model: %s", r.Model)
+	}
+
+	return riskAmount / balance * 100, nil
+}
+
+// FixedSize reports whether the user fixed the size itself, in which case
+// fees are shown but must not shrink it
+func (r Request) FixedSize() bool {
+	return r.Model == ModelNotional || r.Model == ModelQuantity
+}
+
+// String describes the request for the position plan
+func (r Request) String() string {
+	switch r.Model {
+	case ModelRiskUSD:
+		return fmt.Sprintf("$%.2f risk", r.Value)
+	case ModelNotional:
+		return fmt.Sprintf("$%.2f notional", r.Value)
+	case ModelQuantity:
+		return fmt.Sprintf("%g units", r.Value)
+	default:
+		return fmt.Sprintf("%g%% risk", r.Value)
+	}
+}
@@ -0,0 +1,126 @@
+// Package snapshots records timestamped account balances, so the equity
+// curve can be drawn without keeping a spreadsheet.
+package snapshots
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/store"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+const snapshotsFile = "equity.jsonl"
+
+// MinInterval is the closest two snapshots of an account are recorded, so
+// watch mode doesn't write one per refresh
+const MinInterval = 5 * time.Minute
+
+// Snapshot is an account balance at a point in time
+type Snapshot struct {
+	At            time.Time `json:"at"`
+	Account       string    `json:"account"`
+	Total         float64   `json:"total"`
+	Available     float64   `json:"available"`
+	UnrealizedPnL float64   `json:"unrealized_pnl"`
+	Equity        float64   `json:"equity"` // Total + unrealized PnL
+}
+
+// FromBalance builds a snapshot of an account balance taken now
+func FromBalance(account string, balance *broker.Balance) Snapshot {
+	return Snapshot{
+		At:            time.Now().UTC(),
+		Account:       account,
+		Total:         balance.Total,
+		Available:     balance.Available,
+		UnrealizedPnL: balance.UnrealizedPnL,
+		Equity:        balance.Total + balance.UnrealizedPnL,
+	}
+}
+
+// Log appends and queries snapshots in the local store
+type Log struct {
+	store *store.Store
+
+	mu   sync.Mutex
+	last map[string]time.Time // account -> latest snapshot, loaded lazily
+}
+
+// New creates a snapshot log backed by the given store
+func New(st *store.Store) *Log {
+	return &Log{store: st}
+}
+
+// Record appends a snapshot unless the account already has one within
+// MinInterval, reporting whether it was written
+func (l *Log) Record(snapshot Snapshot) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.last == nil {
+		l.last = make(map[string]time.Time)
+		err := l.store.ReadLines(snapshotsFile, func(line []byte) error {
+			var s Snapshot
+			if err := json.Unmarshal(line, &s); err != nil {
+				return fmt.Errorf("corrupt equity snapshot: %w", err)
+			}
+			if s.At.After(l.last[s.Account]) {
+				l.last[s.Account] = s.At
+			}
+			return nil
+		})
+		if err != nil {
+			l.last = nil
+			return false, err
+		}
+	}
+
+	if snapshot.At.Sub(l.last[snapshot.Account]) < MinInterval {
+		return false, nil
+	}
+	if err := l.store.Append(snapshotsFile, snapshot); err != nil {
+		return false, err
+	}
+	l.last[snapshot.Account] = snapshot.At
+	return true, nil
+}
+
+// Series returns an account's snapshots taken from from onwards, oldest
+// first
+func (l *Log) Series(account string, from time.Time) ([]Snapshot, error) {
+	series := []Snapshot{}
+	err := l.store.ReadLines(snapshotsFile, func(line []byte) error {
+		var s Snapshot
+		if err := json.Unmarshal(line, &s); err != nil {
+			return fmt.Errorf("corrupt equity snapshot: %w", err)
+		}
+		if s.Account == account && !s.At.Before(from) {
+			series = append(series, s)
+		}
+		return nil
+	})
+	return series, err
+}
+
+// Return is the percent change in equity from the last snapshot at or
+// before since to the latest one; ok is false when the series doesn't
+// reach back that far
+func Return(series []Snapshot, since time.Time) (float64, bool) {
+	if len(series) < 2 || series[0].At.After(since) {
+		return 0, false
+	}
+
+	base := series[0]
+	for _, s := range series {
+		if s.At.After(since) {
+			break
+		}
+		base = s
+	}
+	if base.Equity <= 0 {
+		return 0, false
+	}
+	return (series[len(series)-1].Equity - base.Equity) / base.Equity * 100, true
+}
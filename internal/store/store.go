@@ -0,0 +1,152 @@
+// Package store persists local CLI state (sessions, snapshots, caches) as
+// JSON files under a data directory.
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store reads and writes JSON documents in a directory
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// ResolveDir returns the data directory under base, defaulting to
+// TRADING_CLI_HOME or ~/.trading-cli. Demo and live state are kept apart
+// so testnet activity never mixes with real account history.
+func ResolveDir(base string, demo bool) string {
+	if base == "" {
+		base = os.Getenv("TRADING_CLI_HOME")
+	}
+	if base == "" {
+		base = filepath.Join(os.Getenv("HOME"), ".trading-cli")
+	}
+
+	if demo {
+		return filepath.Join(base, "demo")
+	}
+	return filepath.Join(base, "live")
+}
+
+// Open creates the directory if needed and returns a store rooted there
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Dir returns the store's root directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Load decodes a JSON document into v. A missing document leaves v untouched.
+func (s *Store) Load(name string, v interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// Save atomically writes v as a JSON document
+func (s *Store) Save(name string, v interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+
+	path := s.path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", name, err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Append adds v as one line to a JSON Lines log
+func (s *Store) Append(name string, v interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s entry: %w", name, err)
+	}
+
+	path := s.path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", name, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to append to %s: %w", name, err)
+	}
+	return nil
+}
+
+// ReadLines calls fn with each line of a JSON Lines log. A missing log
+// yields no lines.
+func (s *Store) ReadLines(name string, fn func(line []byte) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := fn(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
@@ -0,0 +1,119 @@
+// Package strategies maps the strategy types that can be declared in config
+// to strategy-go implementations, so named strategies ("scalp", "swing")
+// are configured rather than compiled in.
+package strategies
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/agatticelli/strategy-go"
+)
+
+// Factory builds a strategy from its configured parameters, with defaults
+// already applied
+type Factory func(params map[string]float64) (strategy.Strategy, error)
+
+// Param describes a numeric parameter accepted by a strategy type
+type Param struct {
+	Name        string
+	Description string
+	Default     float64
+}
+
+// Registration describes a strategy type that can be referenced from config
+type Registration struct {
+	Type        string
+	Description string
+	Params      []Param
+	New         Factory
+}
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]*Registration)
+)
+
+// Register makes a strategy type available. It panics on duplicate or
+// incomplete registrations, since those are programming errors.
+func Register(reg Registration) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if reg.Type == "" || reg.New == nil {
+		panic("strategies: registration requires a type and a factory")
+	}
+	if _, exists := registry[reg.Type]; exists {
+		panic("strategies: duplicate registration for " + reg.Type)
+	}
+
+	registry[reg.Type] = &reg
+}
+
+// Lookup returns the registration for a strategy type
+func Lookup(typ string) (*Registration, error) {
+	mu.RLock()
+	reg, ok := registry[typ]
+	mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy type: %s (available: %s)", typ, strings.Join(Types(), ", "))
+	}
+	return reg, nil
+}
+
+// Types returns all registered strategy types in sorted order
+func Types() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	types := make([]string, 0, len(registry))
+	for typ := range registry {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Resolve checks params against the type's schema and fills in defaults
+func (r *Registration) Resolve(params map[string]float64) (map[string]float64, error) {
+	resolved := make(map[string]float64, len(r.Params))
+	known := make(map[string]bool, len(r.Params))
+	for _, param := range r.Params {
+		known[param.Name] = true
+		resolved[param.Name] = param.Default
+	}
+
+	for name, value := range params {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown parameter %q for strategy type %s (accepted: %s)", name, r.Type, r.paramNames())
+		}
+		resolved[name] = value
+	}
+
+	return resolved, nil
+}
+
+// Build resolves params and creates the strategy
+func (r *Registration) Build(params map[string]float64) (strategy.Strategy, error) {
+	resolved, err := r.Resolve(params)
+	if err != nil {
+		return nil, err
+	}
+	return r.New(resolved)
+}
+
+// paramNames lists accepted parameter names for error messages
+func (r *Registration) paramNames() string {
+	if len(r.Params) == 0 {
+		return "none"
+	}
+
+	names := make([]string, len(r.Params))
+	for i, param := range r.Params {
+		names[i] = param.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,25 @@
+package strategies
+
+import (
+	"fmt"
+
+	"github.com/agatticelli/strategy-go"
+	"github.com/agatticelli/strategy-go/strategies/riskratio"
+)
+
+func init() {
+	Register(Registration{
+		Type:        "riskratio",
+		Description: "Risk-based size with a take profit at a fixed risk-reward ratio",
+		Params: []Param{
+			{Name: "rr", Description: "Risk-reward ratio for the take profit", Default: 2.0},
+		},
+		New: func(params map[string]float64) (strategy.Strategy, error) {
+			rr := params["rr"]
+			if rr <= 0 {
+				return nil, fmt.Errorf("rr must be positive")
+			}
+			return riskratio.New(rr), nil
+		},
+	})
+}
@@ -0,0 +1,107 @@
+// Package symbols caches exchange symbol rules locally so orders can be
+// rounded to tick size and lot step without a round trip on every command.
+package symbols
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/brokers"
+	"github.com/agatticelli/trading-cli/internal/market"
+	"github.com/agatticelli/trading-cli/internal/store"
+)
+
+// DefaultTTL is how long cached symbol rules are trusted
+const DefaultTTL = 24 * time.Hour
+
+// ErrUnknownSymbol is returned when the exchange doesn't list a symbol
+var ErrUnknownSymbol = errors.New("unknown symbol")
+
+// cacheFile is the on-disk form of one broker's symbol list
+type cacheFile struct {
+	FetchedAt time.Time           `json:"fetched_at"`
+	Symbols   []market.SymbolInfo `json:"symbols"`
+}
+
+// Cache serves symbol rules per broker, refreshing them from the exchange
+// once the local copy is older than the TTL
+type Cache struct {
+	store *store.Store
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu     sync.Mutex
+	loaded map[string]map[string]market.SymbolInfo // broker -> symbol -> info
+}
+
+// NewCache creates a cache backed by the given store
+func NewCache(st *store.Store, ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Cache{
+		store:  st,
+		ttl:    ttl,
+		now:    time.Now,
+		loaded: make(map[string]map[string]market.SymbolInfo),
+	}
+}
+
+// Symbols returns every symbol known for a broker. A stale local copy is
+// used when the exchange can't be reached.
+func (c *Cache) Symbols(ctx context.Context, brokerName string, provider brokers.SymbolInfoProvider) (map[string]market.SymbolInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if symbols, ok := c.loaded[brokerName]; ok {
+		return symbols, nil
+	}
+
+	name := "symbols_" + brokerName + ".json"
+
+	var cached cacheFile
+	if err := c.store.Load(name, &cached); err != nil {
+		cached = cacheFile{}
+	}
+
+	if len(cached.Symbols) == 0 || c.now().Sub(cached.FetchedAt) > c.ttl {
+		fresh, err := provider.GetSymbols(ctx)
+		switch {
+		case err == nil && len(fresh) > 0:
+			cached = cacheFile{FetchedAt: c.now().UTC(), Symbols: fresh}
+			if err := c.store.Save(name, cached); err != nil {
+				return nil, err
+			}
+		case len(cached.Symbols) == 0:
+			if err == nil {
+				err = fmt.Errorf("exchange returned no symbols")
+			}
+			return nil, fmt.Errorf("failed to load symbol rules: %w", err)
+		}
+	}
+
+	symbols := make(map[string]market.SymbolInfo, len(cached.Symbols))
+	for _, info := range cached.Symbols {
+		symbols[info.Symbol] = info
+	}
+	c.loaded[brokerName] = symbols
+
+	return symbols, nil
+}
+
+// Lookup returns the rules for one symbol
+func (c *Cache) Lookup(ctx context.Context, brokerName string, provider brokers.SymbolInfoProvider, symbol string) (*market.SymbolInfo, error) {
+	symbols, err := c.Symbols(ctx, brokerName, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	info, ok := symbols[symbol]
+	if !ok {
+		return nil, fmt.Errorf("%w %s on %s", ErrUnknownSymbol, symbol, brokerName)
+	}
+	return &info, nil
+}
@@ -0,0 +1,207 @@
+package symbols
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultQuote is the quote asset assumed when input names only a base asset
+const DefaultQuote = "USDT"
+
+// quoteAssets are recognized when splitting unseparated input like ETHUSDT,
+// longest first so USDT wins over USD
+var quoteAssets = []string{"FDUSD", "USDT", "USDC", "BUSD", "USD"}
+
+// builtinAliases cover common names; config aliases take precedence
+var builtinAliases = map[string]string{
+	"BITCOIN":  "BTC",
+	"XBT":      "BTC",
+	"ETHER":    "ETH",
+	"ETHEREUM": "ETH",
+	"SOLANA":   "SOL",
+	"RIPPLE":   "XRP",
+	"DOGECOIN": "DOGE",
+}
+
+// maxSuggestions bounds the "did you mean" list
+const maxSuggestions = 3
+
+// Resolver turns user input such as "eth", "ether", "eth/usdt" or "ETHUSDT"
+// into an exchange symbol like ETH-USDT
+type Resolver struct {
+	defaultQuote string
+	aliases      map[string]string
+	known        map[string]bool
+}
+
+// NewResolver creates a resolver. Aliases map a name to a base asset (ETH)
+// or a full symbol (ETH-USDC); an empty default quote means USDT.
+func NewResolver(defaultQuote string, aliases map[string]string) *Resolver {
+	if defaultQuote == "" {
+		defaultQuote = DefaultQuote
+	}
+
+	merged := make(map[string]string, len(builtinAliases)+len(aliases))
+	for name, target := range builtinAliases {
+		merged[name] = target
+	}
+	for name, target := range aliases {
+		merged[normalize(name)] = normalize(target)
+	}
+
+	return &Resolver{
+		defaultQuote: strings.ToUpper(defaultQuote),
+		aliases:      merged,
+	}
+}
+
+// SetKnown sets the symbols the exchange lists. Without known symbols,
+// input is normalized but not validated.
+func (r *Resolver) SetKnown(symbols []string) {
+	r.known = make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		r.known[symbol] = true
+	}
+}
+
+// Resolve normalizes input into a listed symbol, suggesting close matches
+// when it isn't listed
+func (r *Resolver) Resolve(input string) (string, error) {
+	token := normalize(input)
+	if token == "" {
+		return "", fmt.Errorf("symbol is empty")
+	}
+
+	if target, ok := r.aliases[token]; ok {
+		token = target
+	}
+
+	base, quote := r.split(token)
+	if target, ok := r.aliases[base]; ok && !strings.Contains(target, "-") {
+		base = target
+	}
+	symbol := base + "-" + quote
+
+	if len(r.known) == 0 || r.known[symbol] {
+		return symbol, nil
+	}
+
+	if suggestions := r.suggest(symbol); len(suggestions) > 0 {
+		return "", fmt.Errorf("%w %s (did you mean %s?)", ErrUnknownSymbol, symbol, strings.Join(suggestions, ", "))
+	}
+	return "", fmt.Errorf("%w %s", ErrUnknownSymbol, symbol)
+}
+
+// split separates a normalized token into base and quote assets
+func (r *Resolver) split(token string) (string, string) {
+	if base, quote, ok := strings.Cut(token, "-"); ok && base != "" && quote != "" {
+		return base, quote
+	}
+	token = strings.Trim(token, "-")
+
+	for _, quote := range quoteAssets {
+		if strings.HasSuffix(token, quote) && len(token) > len(quote) {
+			return strings.TrimSuffix(token, quote), quote
+		}
+	}
+	return token, r.defaultQuote
+}
+
+// suggest returns known symbols within a small edit distance of symbol
+func (r *Resolver) suggest(symbol string) []string {
+	base, quote, _ := strings.Cut(symbol, "-")
+
+	threshold := len(base) / 3
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	type candidate struct {
+		symbol    string
+		distance  int
+		sameQuote bool
+	}
+	best := make(map[string]candidate)
+	consider := func(known string, distance int) {
+		if distance > threshold || !r.known[known] {
+			return
+		}
+		if c, ok := best[known]; ok && c.distance <= distance {
+			return
+		}
+		_, knownQuote, _ := strings.Cut(known, "-")
+		best[known] = candidate{symbol: known, distance: distance, sameQuote: knownQuote == quote}
+	}
+
+	for known := range r.known {
+		knownBase, _, _ := strings.Cut(known, "-")
+		consider(known, min(levenshtein(symbol, known), levenshtein(base, knownBase)))
+	}
+
+	// Misspelled aliases ("bitcoinn") point at the alias target
+	for name, target := range r.aliases {
+		if !strings.Contains(target, "-") {
+			target += "-" + quote
+		}
+		consider(target, levenshtein(base, name))
+	}
+
+	candidates := make([]candidate, 0, len(best))
+	for _, c := range best {
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		if candidates[i].sameQuote != candidates[j].sameQuote {
+			return candidates[i].sameQuote
+		}
+		return candidates[i].symbol < candidates[j].symbol
+	})
+
+	suggestions := make([]string, 0, maxSuggestions)
+	for _, c := range candidates {
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+		suggestions = append(suggestions, c.symbol)
+	}
+	return suggestions
+}
+
+// normalize upper-cases input, unifies separators and drops perpetual suffixes
+func normalize(input string) string {
+	token := strings.ToUpper(strings.TrimSpace(input))
+	token = strings.NewReplacer("/", "-", "_", "-", " ", "-", ":", "-").Replace(token)
+	for _, suffix := range []string{"-PERP", "PERP", "-SWAP"} {
+		if strings.HasSuffix(token, suffix) && len(token) > len(suffix) {
+			token = strings.TrimSuffix(token, suffix)
+			break
+		}
+	}
+	return token
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
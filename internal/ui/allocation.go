@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/agatticelli/trading-cli/internal/sizing"
+)
+
+// FormatAllocation renders how one trade is split across accounts, with
+// the sizes that are placed. Risk (before fees) and notional are shown at
+// the reference entry and stop.
+func FormatAllocation(symbol string, byEquity bool, request sizing.Request, entry, stop float64, allocations []sizing.Allocation) string {
+	var output strings.Builder
+	distance := math.Abs(entry - stop)
+
+	output.WriteString(Section("Allocation") + "\n")
+	split := "weights"
+	if byEquity {
+		split = "equity share"
+	}
+	output.WriteString(KeyValue("Request", fmt.Sprintf("%s across accounts, split by %s", request, split)) + "\n")
+	weightHeader := "Weight"
+	if byEquity {
+		weightHeader = "Equity"
+	}
+	table := NewTable("Account", weightHeader, "Share", "Size", "Risk", "Notional", "Status")
+	var allocated float64
+	for _, allocation := range allocations {
+		weight := fmt.Sprintf("%g", allocation.Weight)
+		if byEquity {
+			weight = FormatMoney(allocation.Weight)
+		}
+		if !allocation.Allocated() {
+			table.AddRow(allocation.Account, weight, MutedStyle.Render("-"), MutedStyle.Render("-"),
+				MutedStyle.Render("-"), MutedStyle.Render("-"), WarningStyle.Render(allocation.Note))
+			continue
+		}
+
+		allocated += allocation.Size
+		table.AddRow(
+			BoldStyle.Render(allocation.Account),
+			weight,
+			fmt.Sprintf("%.1f%%", allocation.Share*100),
+			FormatSize(symbol, allocation.Size),
+			FormatMoney(allocation.Size*distance),
+			FormatMoney(allocation.Size*entry),
+			SuccessStyle.Render("allocated"),
+		)
+	}
+	output.WriteString(table.Render())
+	output.WriteString(KeyValue("Allocated", fmt.Sprintf("%s %s (%s at risk, %s notional)",
+		FormatSize(symbol, allocated), symbol, FormatMoney(allocated*distance), FormatMoney(allocated*entry))) + "\n")
+
+	return output.String()
+}
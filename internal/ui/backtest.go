@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/analytics"
+	"github.com/agatticelli/trading-cli/internal/backtest"
+)
+
+// FormatBacktestReport renders a backtest's summary, equity curve and R
+// distribution
+func FormatBacktestReport(result *backtest.Result) string {
+	var output strings.Builder
+
+	outcomes := make([]analytics.Outcome, len(result.Trades))
+	var fees float64
+	for i, trade := range result.Trades {
+		outcomes[i] = analytics.Outcome{PnL: trade.PnL, R: trade.R, HasR: true}
+		fees += trade.Fees
+	}
+	summary := analytics.Summarize(outcomes)
+
+	equity := make([]float64, len(result.Equity))
+	for i, point := range result.Equity {
+		equity[i] = point.Equity
+	}
+	drawdown := analytics.MaxDrawdown(equity)
+
+	title := "Backtest"
+	if result.Symbol != "" {
+		title += " " + result.Symbol
+	}
+	output.WriteString(Section(title) + "\n")
+	if len(result.Equity) > 0 {
+		first, last := result.Equity[0].Time, result.Equity[len(result.Equity)-1].Time
+		output.WriteString(KeyValue("Period", fmt.Sprintf("%s → %s (%d candles)",
+			first.Format(time.DateOnly), last.Format(time.DateOnly), len(result.Equity))) + "\n")
+	}
+	output.WriteString(KeyValue("Trades", fmt.Sprintf("%d (%d won, %d lost, %d signals skipped)",
+		summary.Trades, summary.Wins, summary.Losses, result.Skipped)) + "\n")
+	output.WriteString(KeyValue("Win Rate", fmt.Sprintf("%.1f%%", summary.WinRate)) + "\n")
+	output.WriteString(KeyValue("Avg Win / Loss", FormatMoney(summary.AvgWin)+" / "+FormatMoney(summary.AvgLoss)) + "\n")
+	output.WriteString(KeyValue("Expectancy", fmt.Sprintf("%s (%.2fR) per trade", FormatMoney(summary.Expectancy), summary.ExpectancyR)) + "\n")
+	output.WriteString(KeyValue("Fees", FormatMoney(fees)) + "\n")
+	output.WriteString(KeyValue("Equity", fmt.Sprintf("%s → %s", FormatMoney(result.Initial), FormatMoney(result.Final))) + "\n")
+	output.WriteString(KeyValue("Return", Percent((result.Final-result.Initial)/result.Initial*100)) + "\n")
+	if drawdown.Percent > 0 {
+		output.WriteString(KeyValue("Max Drawdown", fmt.Sprintf("%.2f%% (%s, %s → %s)", drawdown.Percent, FormatMoney(drawdown.Amount),
+			result.Equity[drawdown.Peak].Time.Format(time.DateOnly), result.Equity[drawdown.Trough].Time.Format(time.DateOnly))) + "\n")
+	} else {
+		output.WriteString(KeyValue("Max Drawdown", "none") + "\n")
+	}
+
+	if len(equity) > 1 {
+		output.WriteString(Section("Equity Curve") + "\n")
+		output.WriteString(DrawdownChart(equity, 60, 10, drawdown))
+	}
+
+	if summary.Trades > 0 {
+		output.WriteString(Section("R Distribution") + "\n")
+		output.WriteString(formatDistribution(analytics.RDistribution(outcomes)))
+	}
+
+	for i, warning := range result.Warnings {
+		if i == 3 {
+			output.WriteString(Warning(fmt.Sprintf("... %d more signals skipped", len(result.Warnings)-i)) + "\n")
+			break
+		}
+		output.WriteString(Warning("Signal skipped at "+warning) + "\n")
+	}
+
+	return output.String()
+}
+
+// formatDistribution renders R buckets as a horizontal bar chart
+func formatDistribution(buckets []analytics.Bucket) string {
+	most := 0
+	for _, bucket := range buckets {
+		most = max(most, bucket.Count)
+	}
+
+	table := NewTable("R", "Trades", "")
+	for _, bucket := range buckets {
+		bar := ""
+		if most > 0 {
+			bar = strings.Repeat("█", bucket.Count*30/most)
+		}
+		table.AddRow(bucket.Label, fmt.Sprintf("%d", bucket.Count), InfoStyle.Render(bar))
+	}
+	return table.Render()
+}
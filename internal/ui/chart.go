@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/agatticelli/trading-cli/internal/analytics"
+)
+
+// Chart renders a series as an ASCII line chart with a money axis. Long
+// series are resampled to the width, keeping each column's last value.
+func Chart(values []float64, width, height int) string {
+	return chart(values, width, height, nil)
+}
+
+// DrawdownChart is Chart with the peak (▲) and trough (▼) of a drawdown
+// marked on the line
+func DrawdownChart(values []float64, width, height int, dd analytics.Drawdown) string {
+	if dd.Percent <= 0 {
+		return Chart(values, width, height)
+	}
+	return chart(values, width, height, map[int]string{
+		dd.Peak:   SuccessStyle.Render("▲"),
+		dd.Trough: ErrorStyle.Render("▼"),
+	})
+}
+
+// chart draws the line, replacing the points at the given value indexes
+// with styled markers
+func chart(values []float64, width, height int, marks map[int]string) string {
+	if len(values) == 0 || width < 2 || height < 2 {
+		return ""
+	}
+
+	columns := resample(values, width)
+	low, high := columns[0], columns[0]
+	for _, v := range columns {
+		low = min(low, v)
+		high = max(high, v)
+	}
+
+	// Row 0 is the top of the chart
+	rowOf := func(v float64) int {
+		if high == low {
+			return height / 2
+		}
+		return height - 1 - int((v-low)/(high-low)*float64(height-1)+0.5)
+	}
+
+	grid := make([][]rune, height)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", len(columns)))
+	}
+	prev := -1
+	for col, v := range columns {
+		row := rowOf(v)
+		if prev >= 0 {
+			// Join to the previous column so steps read as a line
+			for r := min(prev, row) + 1; r < max(prev, row); r++ {
+				grid[r][col] = '│'
+			}
+		}
+		grid[row][col] = '•'
+		prev = row
+	}
+
+	// Markers sit on the column their value was resampled into
+	markAt := make(map[[2]int]string, len(marks))
+	for index, mark := range marks {
+		col := columnOf(index, len(values), len(columns))
+		markAt[[2]int{rowOf(columns[col]), col}] = mark
+	}
+
+	topLabel, bottomLabel := FormatMoney(high), FormatMoney(low)
+	labelWidth := max(len(topLabel), len(bottomLabel))
+
+	var output strings.Builder
+	for i, line := range grid {
+		label := ""
+		switch i {
+		case 0:
+			label = topLabel
+		case height - 1:
+			label = bottomLabel
+		}
+		output.WriteString("  " + MutedStyle.Render(strings.Repeat(" ", labelWidth-len(label))+label) + " ┤")
+
+		start := 0
+		for col := range line {
+			if mark, ok := markAt[[2]int{i, col}]; ok {
+				output.WriteString(InfoStyle.Render(string(line[start:col])) + mark)
+				start = col + 1
+			}
+		}
+		output.WriteString(InfoStyle.Render(string(line[start:])) + "\n")
+	}
+	return output.String()
+}
+
+// columnOf maps a value index to its column after resampling n values
+// into width columns
+func columnOf(index, n, width int) int {
+	if n <= width {
+		return index
+	}
+	for col := 0; col < width; col++ {
+		if (col+1)*n/width-1 >= index {
+			return col
+		}
+	}
+	return width - 1
+}
+
+// resample reduces values to at most width points, keeping the last value
+// of each bucket
+func resample(values []float64, width int) []float64 {
+	if len(values) <= width {
+		return values
+	}
+
+	columns := make([]float64, width)
+	for col := range columns {
+		end := (col+1)*len(values)/width - 1
+		columns[col] = values[end]
+	}
+	return columns
+}
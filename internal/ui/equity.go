@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/analytics"
+	"github.com/agatticelli/trading-cli/internal/snapshots"
+)
+
+// returnWindows are the look-backs of the period returns table
+var returnWindows = []struct {
+	label  string
+	window time.Duration
+}{
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+	{"30d", 30 * 24 * time.Hour},
+	{"90d", 90 * 24 * time.Hour},
+}
+
+// FormatEquityHistory renders an account's equity curve with its max
+// drawdown marked, followed by returns over standard look-backs
+func FormatEquityHistory(series []snapshots.Snapshot) string {
+	if len(series) < 2 {
+		return Info("Not enough snapshots yet; each 'balance' run (and --watch refresh) records one")
+	}
+
+	var output strings.Builder
+	first, last := series[0], series[len(series)-1]
+
+	equity := make([]float64, len(series))
+	for i, s := range series {
+		equity[i] = s.Equity
+	}
+	drawdown := analytics.MaxDrawdown(equity)
+
+	output.WriteString(Section("Equity Curve") + "\n")
+	output.WriteString(KeyValue("Period", fmt.Sprintf("%s → %s (%d snapshots)",
+		first.At.Local().Format("2006-01-02 15:04"), last.At.Local().Format("2006-01-02 15:04"), len(series))) + "\n")
+	output.WriteString(KeyValue("Equity", fmt.Sprintf("%s → %s", FormatMoney(first.Equity), FormatMoney(last.Equity))) + "\n")
+	if first.Equity > 0 {
+		output.WriteString(KeyValue("Change", fmt.Sprintf("%s (%s)",
+			Money(last.Equity-first.Equity), Percent((last.Equity-first.Equity)/first.Equity*100))) + "\n")
+	}
+	if drawdown.Percent > 0 {
+		peak, trough := series[drawdown.Peak], series[drawdown.Trough]
+		output.WriteString(KeyValue("Max Drawdown", fmt.Sprintf("%.2f%% (%s)", drawdown.Percent, FormatMoney(drawdown.Amount))) + "\n")
+		output.WriteString(KeyValue("", fmt.Sprintf("%s peak %s on %s, %s trough %s on %s",
+			SuccessStyle.Render("▲"), FormatMoney(peak.Equity), peak.At.Local().Format("01-02 15:04"),
+			ErrorStyle.Render("▼"), FormatMoney(trough.Equity), trough.At.Local().Format("01-02 15:04"))) + "\n")
+	} else {
+		output.WriteString(KeyValue("Max Drawdown", "none") + "\n")
+	}
+	output.WriteString("\n" + DrawdownChart(equity, 60, 10, drawdown))
+
+	table := NewTable("Period", "Return")
+	for _, w := range returnWindows {
+		ret, ok := snapshots.Return(series, last.At.Add(-w.window))
+		if !ok {
+			table.AddRow(w.label, MutedStyle.Render("-"))
+			continue
+		}
+		table.AddRow(w.label, Percent(ret))
+	}
+	if ret, ok := snapshots.Return(series, first.At); ok {
+		table.AddRow("All", Percent(ret))
+	}
+	output.WriteString(Section("Returns") + "\n")
+	output.WriteString(table.Render())
+
+	return output.String()
+}
@@ -2,8 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 
 	"github.com/agatticelli/calculator-go"
+	"github.com/agatticelli/trading-cli/internal/risk"
 	"github.com/agatticelli/trading-go/broker"
 )
 
@@ -55,12 +59,12 @@ func FormatPosition(pos *broker.Position) string {
 		pnlStr = MutedStyle.Render("0.00")
 	}
 
-	return fmt.Sprintf("  %s %s %s | %.4f @ %.2f | PnL: %s | %dx",
+	return fmt.Sprintf("  %s %s %s | %s @ %.*f | PnL: %s | %dx",
 		sideIcon,
 		sideStyle.Render(pos.Symbol),
 		MutedStyle.Render(string(pos.Side)),
-		pos.Size,
-		pos.EntryPrice,
+		FormatSize(pos.Symbol, pos.Size),
+		PriceDecimals(pos.Symbol, pos.EntryPrice), pos.EntryPrice,
 		pnlStr,
 		pos.Leverage,
 	)
@@ -76,17 +80,17 @@ func FormatOrder(order *broker.Order) string {
 		typeStyle = WarningStyle
 	}
 
-	priceStr := fmt.Sprintf("%.2f", order.Price)
+	priceStr := fmt.Sprintf("%.*f", PriceDecimals(order.Symbol, order.Price), order.Price)
 	if order.Price == 0 && order.StopPrice > 0 {
-		priceStr = fmt.Sprintf("@ %.2f", order.StopPrice)
+		priceStr = fmt.Sprintf("@ %.*f", PriceDecimals(order.Symbol, order.StopPrice), order.StopPrice)
 	}
 
-	return fmt.Sprintf("  %s %s | %s %s | %.4f %s | %s",
+	return fmt.Sprintf("  %s %s | %s %s | %s %s | %s",
 		IconOrder,
 		MutedStyle.Render(order.ID),
 		BoldStyle.Render(order.Symbol),
 		MutedStyle.Render(string(order.Side)),
-		order.Size,
+		FormatSize(order.Symbol, order.Size),
 		priceStr,
 		typeStyle.Render(string(order.Type)),
 	)
@@ -96,39 +100,39 @@ func FormatOrder(order *broker.Order) string {
 func FormatPositionPlan(symbol string, size, entry, sl, tp float64, leverage int, risk, notional float64) string {
 	data := map[string]string{
 		"Symbol":     symbol,
-		"Size":       fmt.Sprintf("%.4f", size),
-		"Entry":      FormatMoney(entry),
-		"Stop Loss":  FormatMoney(sl),
+		"Size":       FormatSize(symbol, size),
+		"Entry":      FormatPrice(symbol, entry),
+		"Stop Loss":  FormatPrice(symbol, sl),
 		"Leverage":   fmt.Sprintf("%dx", leverage),
 		"Risk":       FormatMoney(risk),
 		"Notional":   FormatMoney(notional),
 	}
 
 	if tp > 0 {
-		data["Take Profit"] = FormatMoney(tp)
+		data["Take Profit"] = FormatPrice(symbol, tp)
 	}
 
 	return "\n" + Box("Position Plan", RenderSimpleTable(data))
 }
 
-// FormatPositionsTable formats multiple positions as a table with TP/SL targets
-func FormatPositionsTable(positions []*broker.Position, orders []*broker.Order) string {
+// PositionDetails carries what exchanges don't report on broker.Position
+type PositionDetails struct {
+	Liquidation float64 // Zero when unknown or unreachable
+	Reported    bool    // Liquidation is the exchange's own, not an estimate
+	MarginMode  string  // isolated, cross, or empty when unknown
+}
+
+// FormatPositionsTable formats multiple positions as a table with TP/SL targets,
+// liquidation prices and margin mode (details keyed by symbol)
+func FormatPositionsTable(positions []*broker.Position, orders []*broker.Order, details map[string]PositionDetails) string {
 	if len(positions) == 0 {
 		return Info("No open positions")
 	}
 
 	// Create order map by symbol and type for quick lookup
-	orderMap := make(map[string]map[broker.OrderType]*broker.Order)
-	if orders != nil {
-		for _, order := range orders {
-			if orderMap[order.Symbol] == nil {
-				orderMap[order.Symbol] = make(map[broker.OrderType]*broker.Order)
-			}
-			orderMap[order.Symbol][order.Type] = order
-		}
-	}
+	orderMap := risk.OrdersBySymbol(orders)
 
-	table := NewTable("Symbol", "Side", "Size", "Entry", "Mark", "PnL", "PnL %", "To TP", "To SL", "Leverage")
+	table := NewTable("Symbol", "Side", "Size", "Entry", "Mark", "Liq.", "PnL", "PnL %", "To TP", "To SL", "Leverage", "Margin")
 
 	for _, pos := range positions {
 		// Side with icon and color
@@ -198,17 +202,38 @@ func FormatPositionsTable(positions []*broker.Position, orders []*broker.Order)
 			}
 		}
 
+		// Liquidation, marked ~ when estimated and highlighted when mark
+		// is within 10% of it
+		liqStr := MutedStyle.Render("-")
+		detail := details[pos.Symbol]
+		if liq := detail.Liquidation; liq > 0 {
+			liqStr = FormatPrice(pos.Symbol, liq)
+			if !detail.Reported {
+				liqStr = "~" + liqStr
+			}
+			if pos.MarkPrice > 0 && math.Abs(pos.MarkPrice-liq)/pos.MarkPrice < 0.10 {
+				liqStr = ErrorStyle.Render(liqStr)
+			}
+		}
+
+		marginStr := MutedStyle.Render("-")
+		if detail.MarginMode != "" {
+			marginStr = detail.MarginMode
+		}
+
 		table.AddRow(
 			BoldStyle.Render(pos.Symbol),
 			sideStr,
-			fmt.Sprintf("%.4f", pos.Size),
-			FormatMoney(pos.EntryPrice),
-			FormatMoney(pos.MarkPrice),
+			FormatSize(pos.Symbol, pos.Size),
+			FormatPrice(pos.Symbol, pos.EntryPrice),
+			FormatPrice(pos.Symbol, pos.MarkPrice),
+			liqStr,
 			pnlStr,
 			pnlPercentStr,
 			toTPStr,
 			toSLStr,
 			fmt.Sprintf("%dx", pos.Leverage),
+			marginStr,
 		)
 	}
 
@@ -253,9 +278,9 @@ func FormatOrdersTableWithIDs(orders []*broker.Order, positions []*broker.Positi
 		// LIMIT, STOP, TAKE_PROFIT all use InfoStyle (blue) for consistency
 
 		// Price
-		priceStr := FormatMoney(order.Price)
+		priceStr := FormatPrice(order.Symbol, order.Price)
 		if order.Price == 0 && order.StopPrice > 0 {
-			priceStr = MutedStyle.Render("@ " + FormatMoney(order.StopPrice))
+			priceStr = MutedStyle.Render("@ " + FormatPrice(order.Symbol, order.StopPrice))
 		}
 
 		// Status with color based on state
@@ -337,7 +362,7 @@ func FormatOrdersTableWithIDs(orders []*broker.Order, positions []*broker.Positi
 			BoldStyle.Render(order.Symbol),
 			sideStr,
 			typeStr,
-			fmt.Sprintf("%.4f", order.Size),
+			FormatSize(order.Symbol, order.Size),
 			priceStr,
 			expectedPnLStr,
 			statusStr,
@@ -346,3 +371,108 @@ func FormatOrdersTableWithIDs(orders []*broker.Order, positions []*broker.Positi
 
 	return table.Render()
 }
+
+// FormatDailyStatus formats the daily PnL session and any lockouts
+func FormatDailyStatus(status *risk.DailyStatus) string {
+	var output strings.Builder
+
+	output.WriteString(Section(fmt.Sprintf("Daily Session %s (resets %s)",
+		status.Session.Key, status.ResetsAt.Local().Format("Jan 2 15:04"))) + "\n")
+
+	accounts := make([]string, 0, len(status.PnL))
+	for account := range status.PnL {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	for _, account := range accounts {
+		line := fmt.Sprintf("  %-12s %s", account, Money(status.PnL[account]))
+		if reason := status.Session.Locked[account]; reason != "" {
+			line += "  " + ErrorStyle.Render("LOCKED") + " " + MutedStyle.Render(reason)
+		}
+		output.WriteString(line + "\n")
+	}
+
+	output.WriteString(fmt.Sprintf("  %-12s %s", "Total", Money(status.TotalPnL)))
+	if status.Session.AllLocked != "" {
+		output.WriteString("  " + ErrorStyle.Render("LOCKED") + " " + MutedStyle.Render(status.Session.AllLocked))
+	}
+
+	return output.String()
+}
+
+// FormatRiskReport formats the per-position heat table for one account
+func FormatRiskReport(report risk.AccountRisk) string {
+	if len(report.Positions) == 0 {
+		return Info("No open positions")
+	}
+
+	table := NewTable("Symbol", "Side", "Size", "Entry", "Stop", "At Risk", "% Equity", "Locked", "R Locked")
+
+	for _, pos := range report.Positions {
+		sideStr := LongStyle.Render(IconLong + " LONG")
+		if pos.Side == broker.SideShort {
+			sideStr = ShortStyle.Render(IconShort + " SHORT")
+		}
+
+		stopStr := ErrorStyle.Render("NONE")
+		atRiskStr := ErrorStyle.Render("unbounded")
+		percentStr := MutedStyle.Render("-")
+		if pos.HasStop {
+			stopStr = FormatPrice(pos.Symbol, pos.StopPrice)
+			atRiskStr = FormatMoney(pos.AtRisk)
+			if report.Equity > 0 {
+				percentStr = fmt.Sprintf("%.2f%%", pos.AtRisk/report.Equity*100)
+			}
+		}
+
+		lockedStr := MutedStyle.Render("-")
+		if pos.Locked > 0 {
+			lockedStr = SuccessStyle.Render("+" + FormatMoney(pos.Locked))
+		}
+
+		rStr := MutedStyle.Render("-")
+		if pos.RLocked != nil {
+			rStr = SuccessStyle.Render(fmt.Sprintf("+%.2fR", *pos.RLocked))
+		}
+
+		table.AddRow(
+			BoldStyle.Render(pos.Symbol),
+			sideStr,
+			FormatSize(pos.Symbol, pos.Size),
+			FormatPrice(pos.Symbol, pos.EntryPrice),
+			stopStr,
+			atRiskStr,
+			percentStr,
+			lockedStr,
+			rStr,
+		)
+	}
+
+	return table.Render() + FormatRiskTotal(report)
+}
+
+// FormatRiskTotal formats the heat summary for an account or the portfolio
+func FormatRiskTotal(report risk.AccountRisk) string {
+	var output strings.Builder
+
+	title := "Portfolio Heat"
+	if report.Account != "TOTAL" {
+		title = "Account Heat"
+	}
+	output.WriteString(Section(title) + "\n")
+
+	output.WriteString(KeyValue("Equity", FormatMoney(report.Equity)) + "\n")
+	output.WriteString(KeyValue("At Risk", fmt.Sprintf("%s (%.2f%% of equity)", FormatMoney(report.AtRisk), report.AtRiskPercent)) + "\n")
+	output.WriteString(KeyValue("Locked In", fmt.Sprintf("%s (%.2fR)", FormatMoney(report.Locked), report.RLocked)) + "\n")
+	output.WriteString(KeyValue("Long Exposure", FormatMoney(report.LongNotional)) + "\n")
+	output.WriteString(KeyValue("Short Exposure", FormatMoney(report.ShortNotional)) + "\n")
+	output.WriteString(KeyValue("Net Exposure", FormatMoney(report.NetNotional)) + "\n")
+
+	if len(report.Unprotected) > 0 {
+		output.WriteString(Warning(fmt.Sprintf("No stop on %s (%s notional unbounded)",
+			strings.Join(report.Unprotected, ", "), FormatMoney(report.UnprotectedNotional))) + "\n")
+	}
+
+	return output.String()
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agatticelli/trading-cli/internal/history"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// FormatTradeHistory formats closed round trips, oldest first, with a
+// totals line
+func FormatTradeHistory(trades []history.Trade) string {
+	if len(trades) == 0 {
+		return Info("No closed trades in this period")
+	}
+
+	table := NewTable("Closed", "Symbol", "Side", "Size", "Entry", "Exit", "Held", "Fees", "PnL", "R")
+
+	var net, fees float64
+	otherFees := 0
+	for _, trade := range trades {
+		sideStr := LongStyle.Render(IconLong + " LONG")
+		if trade.Side == broker.SideShort {
+			sideStr = ShortStyle.Render(IconShort + " SHORT")
+		}
+
+		rStr := MutedStyle.Render("-")
+		if trade.R != nil {
+			rStr = formatR(*trade.R)
+		}
+
+		feesStr := FormatMoney(trade.Fees)
+		if len(trade.OtherFees) > 0 {
+			feesStr += WarningStyle.Render(" *")
+			otherFees++
+		}
+
+		table.AddRow(
+			trade.ClosedAt.Local().Format("01-02 15:04"),
+			BoldStyle.Render(trade.Symbol),
+			sideStr,
+			FormatSize(trade.Symbol, trade.Size),
+			FormatPrice(trade.Symbol, trade.Entry),
+			FormatPrice(trade.Symbol, trade.Exit),
+			formatHeld(trade.Duration()),
+			feesStr,
+			Money(trade.NetPnL),
+			rStr,
+		)
+
+		net += trade.NetPnL
+		fees += trade.Fees
+	}
+
+	output := table.Render() + KeyValue("Realized", fmt.Sprintf("%s net over %d trade(s), %s in fees",
+		Money(net), len(trades), FormatMoney(fees))) + "\n"
+	if otherFees > 0 {
+		output += Warning(fmt.Sprintf("* %d trade(s) paid fees in another asset (e.g. BNB), not included in fees or PnL", otherFees)) + "\n"
+	}
+	return output
+}
+
+// formatR renders an R multiple colored by sign
+func formatR(r float64) string {
+	if r > 0 {
+		return SuccessStyle.Render(fmt.Sprintf("+%.2fR", r))
+	} else if r < 0 {
+		return ErrorStyle.Render(fmt.Sprintf("%.2fR", r))
+	}
+	return MutedStyle.Render("0.00R")
+}
+
+// formatHeld renders a holding time in its two largest units
+func formatHeld(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return d.Round(time.Second).String()
+	}
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agatticelli/trading-cli/internal/mirror"
+	"github.com/agatticelli/trading-go/broker"
+)
+
+// FormatMirrorReport renders a follower's reconciliation against the
+// leader: sizes, drift and the orders needed per symbol
+func FormatMirrorReport(ratio string, lines []mirror.Line) string {
+	var output strings.Builder
+
+	output.WriteString(KeyValue("Size Ratio", ratio) + "\n")
+	if len(lines) == 0 {
+		output.WriteString(Info("No positions on either side") + "\n")
+		return output.String()
+	}
+
+	table := NewTable("Symbol", "Leader", "Target", "Follower", "Drift", "Status")
+	synced := 0
+	for _, line := range lines {
+		status := SuccessStyle.Render("in sync")
+		if !line.InSync() {
+			actions := make([]string, len(line.Actions))
+			for i, action := range line.Actions {
+				actions[i] = FormatMirrorAction(action)
+			}
+			status = WarningStyle.Render(strings.Join(actions, "; "))
+		} else {
+			synced++
+		}
+		if line.Note != "" {
+			status += MutedStyle.Render(" (" + line.Note + ")")
+		}
+
+		table.AddRow(
+			BoldStyle.Render(line.Symbol),
+			formatMirrorSide(line.Symbol, line.LeaderSide, line.LeaderSize),
+			formatMirrorSide(line.Symbol, line.LeaderSide, line.Target),
+			formatMirrorSide(line.Symbol, line.FollowerSide, line.FollowerSize),
+			fmt.Sprintf("%.1f%%", line.Drift),
+			status,
+		)
+	}
+	output.WriteString(table.Render())
+	output.WriteString(KeyValue("Reconciled", fmt.Sprintf("%d of %d symbol(s) in sync", synced, len(lines))) + "\n")
+
+	return output.String()
+}
+
+// FormatMirrorAction describes an order the follower needs
+func FormatMirrorAction(action mirror.Action) string {
+	size := FormatSize(action.Symbol, action.Size)
+
+	switch action.Kind {
+	case mirror.ActionOpen:
+		return fmt.Sprintf("open %s %s", action.Side, size)
+	case mirror.ActionIncrease:
+		return fmt.Sprintf("add %s to %s", size, action.Side)
+	case mirror.ActionReduce:
+		return fmt.Sprintf("reduce %s by %s", action.Side, size)
+	case mirror.ActionClose:
+		return fmt.Sprintf("close %s %s", action.Side, size)
+	case mirror.ActionProtect:
+		legs := []string{}
+		if action.Protection.Stop > 0 {
+			legs = append(legs, "SL "+FormatPrice(action.Symbol, action.Protection.Stop))
+		}
+		if action.Protection.TakeProfit > 0 {
+			legs = append(legs, "TP "+FormatPrice(action.Symbol, action.Protection.TakeProfit))
+		}
+		if len(legs) == 0 {
+			return "remove SL/TP"
+		}
+		return "set " + strings.Join(legs, " / ")
+	default:
+		return string(action.Kind)
+	}
+}
+
+// formatMirrorSide renders a side and size, or a dash when flat
+func formatMirrorSide(symbol string, side broker.Side, size float64) string {
+	if size == 0 {
+		return MutedStyle.Render("-")
+	}
+	return fmt.Sprintf("%s %s", side, FormatSize(symbol, size))
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Default decimals when a symbol's exchange rules are unknown
+const (
+	defaultPriceDecimals = 2
+	defaultSizeDecimals  = 4
+	maxPriceDecimals     = 8
+)
+
+type symbolPrecision struct {
+	price int
+	size  int
+}
+
+var (
+	precisionMu sync.RWMutex
+	precisions  = make(map[string]symbolPrecision)
+)
+
+// SetSymbolPrecision registers how many decimals a symbol's prices and
+// sizes are shown with, normally taken from the exchange's tick size and
+// lot step
+func SetSymbolPrecision(symbol string, priceDecimals, sizeDecimals int) {
+	precisionMu.Lock()
+	defer precisionMu.Unlock()
+	precisions[symbol] = symbolPrecision{price: priceDecimals, size: sizeDecimals}
+}
+
+// PriceDecimals returns the decimals used for a symbol's prices. Unknown
+// symbols keep four significant digits so sub-dollar coins stay readable.
+func PriceDecimals(symbol string, price float64) int {
+	precisionMu.RLock()
+	p, ok := precisions[symbol]
+	precisionMu.RUnlock()
+	if ok {
+		return p.price
+	}
+
+	price = math.Abs(price)
+	if price == 0 || price >= 1 {
+		return defaultPriceDecimals
+	}
+	decimals := int(-math.Floor(math.Log10(price))) + 3
+	if decimals > maxPriceDecimals {
+		decimals = maxPriceDecimals
+	}
+	return decimals
+}
+
+// SizeDecimals returns the decimals used for a symbol's sizes
+func SizeDecimals(symbol string) int {
+	precisionMu.RLock()
+	defer precisionMu.RUnlock()
+	if p, ok := precisions[symbol]; ok {
+		return p.size
+	}
+	return defaultSizeDecimals
+}
+
+// FormatPrice formats a symbol's price as money at the symbol's precision
+func FormatPrice(symbol string, price float64) string {
+	return formatMoneyDecimals(price, PriceDecimals(symbol, price))
+}
+
+// FormatSize formats a symbol's order or position size at its lot precision
+func FormatSize(symbol string, size float64) string {
+	return fmt.Sprintf("%.*f", SizeDecimals(symbol), size)
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agatticelli/trading-cli/internal/analytics"
+	"github.com/agatticelli/trading-cli/internal/history"
+)
+
+// FormatStats renders a performance report: totals, then per-account and
+// per-symbol tables
+func FormatStats(report analytics.Report) string {
+	var output strings.Builder
+	total := report.Total
+
+	output.WriteString(Section("Performance") + "\n")
+	output.WriteString(KeyValue("Period", history.Period{From: report.From, To: report.To}.String()) + "\n")
+	if total.Trades == 0 {
+		output.WriteString(Info("No closed trades in this period") + "\n")
+		return output.String()
+	}
+
+	output.WriteString(KeyValue("Trades", fmt.Sprintf("%d (%d won, %d lost)", total.Trades, total.Wins, total.Losses)) + "\n")
+	output.WriteString(KeyValue("Win Rate", fmt.Sprintf("%.1f%%", total.WinRate)) + "\n")
+	output.WriteString(KeyValue("Net PnL", Money(total.NetPnL)) + "\n")
+	output.WriteString(KeyValue("Avg Win / Loss", FormatMoney(total.AvgWin)+" / "+FormatMoney(total.AvgLoss)) + "\n")
+	output.WriteString(KeyValue("Profit Factor", formatProfitFactor(total.Summary)) + "\n")
+
+	expectancy := FormatMoney(total.Expectancy) + " per trade"
+	if total.RTrades > 0 {
+		expectancy += fmt.Sprintf(", %.2fR over %d journaled trade(s)", total.ExpectancyR, total.RTrades)
+	}
+	output.WriteString(KeyValue("Expectancy", expectancy) + "\n")
+	output.WriteString(KeyValue("Max Loss Streak", fmt.Sprintf("%d", total.MaxLossStreak)) + "\n")
+	output.WriteString(KeyValue("Sharpe / Sortino", formatRatios(total)+" (daily, annualized)") + "\n")
+
+	if len(report.Symbols) > 1 {
+		best, worst := report.Symbols[0], report.Symbols[len(report.Symbols)-1]
+		output.WriteString(KeyValue("Best Symbol", best.Name+" "+Money(best.NetPnL)) + "\n")
+		output.WriteString(KeyValue("Worst Symbol", worst.Name+" "+Money(worst.NetPnL)) + "\n")
+	}
+
+	if len(report.Accounts) > 1 {
+		output.WriteString(Section("By Account") + "\n")
+		table := NewTable("Account", "Trades", "Win Rate", "Net PnL", "PF", "Exp R", "Loss Streak", "Sharpe", "Sortino")
+		for _, g := range report.Accounts {
+			sharpe, sortino := "-", "-"
+			if g.Sharpe != 0 || g.Sortino != 0 {
+				sharpe, sortino = fmt.Sprintf("%.2f", g.Sharpe), fmt.Sprintf("%.2f", g.Sortino)
+			}
+			table.AddRow(
+				BoldStyle.Render(g.Name),
+				fmt.Sprintf("%d", g.Trades),
+				fmt.Sprintf("%.1f%%", g.WinRate),
+				Money(g.NetPnL),
+				formatProfitFactor(g.Summary),
+				formatExpectancyR(g.Summary),
+				fmt.Sprintf("%d", g.MaxLossStreak),
+				sharpe,
+				sortino,
+			)
+		}
+		output.WriteString(table.Render())
+	}
+
+	output.WriteString(Section("By Symbol") + "\n")
+	table := NewTable("Symbol", "Trades", "Win Rate", "Net PnL", "Avg Win", "Avg Loss", "PF", "Exp R")
+	for _, g := range report.Symbols {
+		table.AddRow(
+			BoldStyle.Render(g.Name),
+			fmt.Sprintf("%d", g.Trades),
+			fmt.Sprintf("%.1f%%", g.WinRate),
+			Money(g.NetPnL),
+			FormatMoney(g.AvgWin),
+			FormatMoney(g.AvgLoss),
+			formatProfitFactor(g.Summary),
+			formatExpectancyR(g.Summary),
+		)
+	}
+	output.WriteString(table.Render())
+
+	return output.String()
+}
+
+// formatProfitFactor renders gross win over gross loss, which is unbounded
+// without losses
+func formatProfitFactor(s analytics.Summary) string {
+	switch {
+	case s.Losses > 0:
+		return fmt.Sprintf("%.2f", s.ProfitFactor)
+	case s.Wins > 0:
+		return "∞"
+	default:
+		return MutedStyle.Render("-")
+	}
+}
+
+// formatExpectancyR renders the average R, when any trade has one
+func formatExpectancyR(s analytics.Summary) string {
+	if s.RTrades == 0 {
+		return MutedStyle.Render("-")
+	}
+	return formatR(s.ExpectancyR)
+}
+
+// formatRatios renders Sharpe and Sortino, when the equity was known
+func formatRatios(g analytics.Group) string {
+	if g.Sharpe == 0 && g.Sortino == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f / %.2f", g.Sharpe, g.Sortino)
+}
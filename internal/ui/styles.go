@@ -155,13 +155,20 @@ func Percent(value float64) string {
 
 // FormatMoney formats a float as money with thousands separators
 func FormatMoney(value float64) string {
-	// Format with 2 decimal places
-	formatted := fmt.Sprintf("%.2f", value)
+	return formatMoneyDecimals(value, 2)
+}
+
+// formatMoneyDecimals formats money with a given number of decimals
+func formatMoneyDecimals(value float64, decimals int) string {
+	formatted := fmt.Sprintf("%.*f", decimals, value)
 
 	// Split into integer and decimal parts
-	parts := strings.Split(formatted, ".")
+	parts := strings.SplitN(formatted, ".", 2)
 	intPart := parts[0]
-	decPart := parts[1]
+	decPart := ""
+	if len(parts) == 2 {
+		decPart = "." + parts[1]
+	}
 
 	// Handle negative sign
 	negative := false
@@ -181,9 +188,9 @@ func FormatMoney(value float64) string {
 
 	// Combine parts
 	if negative {
-		return fmt.Sprintf("-$%s.%s", result.String(), decPart)
+		return fmt.Sprintf("-$%s%s", result.String(), decPart)
 	}
-	return fmt.Sprintf("$%s.%s", result.String(), decPart)
+	return fmt.Sprintf("$%s%s", result.String(), decPart)
 }
 
 // formatPercent formats a float as percentage